@@ -2,7 +2,9 @@ package main
 
 import (
 	"database/sql"
+	"expvar"
 	"github.com/delevopersmoke/ocpp_microservice/internal/config"
+	"github.com/delevopersmoke/ocpp_microservice/internal/files"
 	"github.com/delevopersmoke/ocpp_microservice/internal/handler"
 	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
 	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
@@ -60,13 +62,28 @@ func main() {
 	repo := repository.NewRepository(db)
 	repo.Station.SetAllOffline()
 	grpcServer := grpc.NewServer()
-	controlService := service.NewCommandServiceServer(repo)
+	fileStore := files.New(cfg)
+	controlService := service.NewCommandServiceServer(repo, cfg, fileStore)
+	go service.NewFirmwareCampaignRunner(repo, fileStore).Run()
+	go service.NewReservationExpirer(repo).Run()
 
-	handlers := handler.NewHandler(repo)
-	// Регистрируем маршруты
-	handlers.InitRoutes()
+	handlers := handler.NewHandler(repo, cfg, fileStore)
+	// Регистрируем маршруты на отдельном mux: DefaultServeMux содержит /debug/vars из expvar
+	mux := http.NewServeMux()
+	handlers.InitRoutes(mux)
 	srv := new(Server)
-	go srv.Run("5010", http.DefaultServeMux)
+	go srv.Run("5010", mux)
+
+	// Метрики отдаются только на внутреннем адресе
+	if addr := cfg.MetricsAddr(); addr != "" {
+		metrics := http.NewServeMux()
+		metrics.Handle("/debug/vars", expvar.Handler())
+		go func() {
+			if err := http.ListenAndServe(addr, metrics); err != nil {
+				log.Printf("Ошибка запуска сервера метрик на %s: %v", addr, err)
+			}
+		}()
+	}
 
 	control.RegisterControlServiceServer(grpcServer, controlService)
 
@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/spf13/viper"
 	"strings"
+	"time"
 )
 
 // Здесь будет конфигурация микросервиса
@@ -18,6 +19,165 @@ type Config struct {
 	GRPC struct {
 		Port int
 	}
+	OCPP struct {
+		// HeartbeatInterval - интервал Heartbeat в секундах, который сообщается станции в BootNotification
+		HeartbeatInterval int `mapstructure:"heartbeat_interval"`
+		// MissedHeartbeats - через сколько пропущенных интервалов соединение считается мёртвым
+		MissedHeartbeats int `mapstructure:"missed_heartbeats"`
+		// PingInterval - интервал WebSocket ping в секундах
+		PingInterval int `mapstructure:"ping_interval"`
+		// DuplicateConnection - что делать при повторном подключении станции: replace или reject
+		DuplicateConnection string `mapstructure:"duplicate_connection"`
+		Validation          struct {
+			// Mode - режим проверки JSON-схем для всех станций: strict (по умолчанию), lenient или off
+			Mode string
+			// Stations - режим для отдельных станций по chargeBoxId
+			Stations map[string]string
+		}
+		// ConfigurationProfiles - ключи конфигурации, которые выставляются станциям после каждой загрузки
+		ConfigurationProfiles []ConfigurationProfile `mapstructure:"configuration_profiles"`
+	}
+	Files struct {
+		// Dir - каталог с прошивками (firmware/<версия>/<файл>) и диагностикой (diagnostics/<id станции>/)
+		Dir string
+		// BaseURL - адрес HTTP-сервера, доступный станциям, по которому они скачивают прошивку и выгружают
+		// диагностику. Без него встроенный файловый сервер не выдаёт ссылок
+		BaseURL string `mapstructure:"base_url"`
+		// SigningKey - ключ подписи ссылок. Если не задан, генерируется при запуске
+		SigningKey string `mapstructure:"signing_key"`
+		// URLTTL - время жизни подписанной ссылки в секундах
+		URLTTL int `mapstructure:"url_ttl"`
+		// MaxUploadSize - максимальный размер файла диагностики в мегабайтах
+		MaxUploadSize int64 `mapstructure:"max_upload_size"`
+	}
+	Metrics struct {
+		// Addr - внутренний адрес, на котором отдаются метрики expvar (/debug/vars). "off" - не отдавать
+		Addr string
+	}
+}
+
+// ConfigurationProfile - желаемая конфигурация станций производителя. Пустой Model - все модели
+// производителя, профиль конкретной модели дополняет и переопределяет его
+type ConfigurationProfile struct {
+	Vendor string
+	Model  string
+	Keys   []ConfigurationProfileKey
+}
+
+type ConfigurationProfileKey struct {
+	Key   string
+	Value string
+}
+
+// Политики повторного подключения станции с тем же chargeBoxId
+const (
+	// DuplicateConnectionReplace - новое соединение заменяет старое, старое закрывается
+	DuplicateConnectionReplace = "replace"
+	// DuplicateConnectionReject - новое соединение отклоняется, пока живо старое
+	DuplicateConnectionReject = "reject"
+)
+
+// DuplicateConnectionPolicy возвращает политику повторного подключения
+func (c *Config) DuplicateConnectionPolicy() string {
+	if c.OCPP.DuplicateConnection == DuplicateConnectionReject {
+		return DuplicateConnectionReject
+	}
+	return DuplicateConnectionReplace
+}
+
+// HeartbeatInterval возвращает интервал OCPP Heartbeat
+func (c *Config) HeartbeatInterval() time.Duration {
+	if c.OCPP.HeartbeatInterval <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(c.OCPP.HeartbeatInterval) * time.Second
+}
+
+// MissedHeartbeats возвращает допустимое число пропущенных интервалов Heartbeat
+func (c *Config) MissedHeartbeats() int {
+	if c.OCPP.MissedHeartbeats <= 0 {
+		return 3
+	}
+	return c.OCPP.MissedHeartbeats
+}
+
+// PingInterval возвращает интервал WebSocket ping
+func (c *Config) PingInterval() time.Duration {
+	if c.OCPP.PingInterval <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.OCPP.PingInterval) * time.Second
+}
+
+// ConfigurationProfile возвращает желаемые ключи конфигурации для производителя и модели станции
+func (c *Config) ConfigurationProfile(vendor string, model string) map[string]string {
+	keys := make(map[string]string)
+	for _, withModel := range []bool{false, true} {
+		for _, profile := range c.OCPP.ConfigurationProfiles {
+			if !strings.EqualFold(profile.Vendor, vendor) || (profile.Model != "") != withModel {
+				continue
+			}
+			if withModel && !strings.EqualFold(profile.Model, model) {
+				continue
+			}
+			for _, k := range profile.Keys {
+				keys[k.Key] = k.Value
+			}
+		}
+	}
+	return keys
+}
+
+// FilesDir возвращает каталог файлового сервера
+func (c *Config) FilesDir() string {
+	if c.Files.Dir == "" {
+		return "files"
+	}
+	return c.Files.Dir
+}
+
+// FilesBaseURL возвращает адрес файлового сервера без завершающего /, пусто - адрес не задан
+func (c *Config) FilesBaseURL() string {
+	return strings.TrimRight(c.Files.BaseURL, "/")
+}
+
+// URLTTL возвращает время жизни подписанной ссылки
+func (c *Config) URLTTL() time.Duration {
+	if c.Files.URLTTL <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.Files.URLTTL) * time.Second
+}
+
+// MaxUploadSize возвращает максимальный размер файла диагностики в байтах
+func (c *Config) MaxUploadSize() int64 {
+	if c.Files.MaxUploadSize <= 0 {
+		return 100 << 20
+	}
+	return c.Files.MaxUploadSize << 20
+}
+
+// MetricsAddr возвращает адрес сервера метрик, пусто - метрики не отдаются
+func (c *Config) MetricsAddr() string {
+	switch c.Metrics.Addr {
+	case "":
+		return "127.0.0.1:5011"
+	case "off":
+		return ""
+	}
+	return c.Metrics.Addr
+}
+
+// ValidationMode возвращает режим проверки JSON-схем для станции
+func (c *Config) ValidationMode(chargeBoxId string) string {
+	// viper приводит ключи map к нижнему регистру
+	if mode, ok := c.OCPP.Validation.Stations[strings.ToLower(chargeBoxId)]; ok {
+		return mode
+	}
+	if c.OCPP.Validation.Mode == "" {
+		return "strict"
+	}
+	return c.OCPP.Validation.Mode
 }
 
 func Init(path string) (*Config, error) {
@@ -47,5 +207,14 @@ func unmarshal(cfg *Config) error {
 	if err := viper.UnmarshalKey("grpc", &cfg.GRPC); err != nil {
 		return err
 	}
+	if err := viper.UnmarshalKey("ocpp", &cfg.OCPP); err != nil {
+		return err
+	}
+	if err := viper.UnmarshalKey("files", &cfg.Files); err != nil {
+		return err
+	}
+	if err := viper.UnmarshalKey("metrics", &cfg.Metrics); err != nil {
+		return err
+	}
 	return nil
 }
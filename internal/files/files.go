@@ -0,0 +1,216 @@
+package files
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/config"
+)
+
+// Подписанные ссылки имеют вид <base>/<kind>/<id станции>/<expires>/<signature>/<путь>.
+// Подпись находится в пути, а не в query: станции дописывают имя файла к адресу выгрузки диагностики
+const (
+	KindFirmware    = "firmware"
+	KindDiagnostics = "diagnostics"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid url signature")
+	ErrURLExpired       = errors.New("url expired")
+	ErrInvalidPath      = errors.New("invalid file path")
+	ErrTooLarge         = errors.New("file too large")
+	ErrNoBaseURL        = errors.New("files.base_url is not configured")
+)
+
+// Store хранит прошивки и файлы диагностики и подписывает ссылки на них для станций
+type Store struct {
+	dir           string
+	baseURL       string
+	key           []byte
+	ttl           time.Duration
+	maxUploadSize int64
+}
+
+func New(cfg *config.Config) *Store {
+	key := []byte(cfg.Files.SigningKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+		log.Println("files.signing_key не задан, ссылки на файлы перестанут действовать после перезапуска")
+	}
+	if cfg.FilesBaseURL() == "" {
+		log.Println("files.base_url не задан, ссылки на прошивку и выгрузку диагностики выдаваться не будут")
+	}
+	return &Store{
+		dir:           cfg.FilesDir(),
+		baseURL:       cfg.FilesBaseURL(),
+		key:           key,
+		ttl:           cfg.URLTTL(),
+		maxUploadSize: cfg.MaxUploadSize(),
+	}
+}
+
+// MaxUploadSize - максимальный размер файла диагностики в байтах
+func (s *Store) MaxUploadSize() int64 {
+	return s.maxUploadSize
+}
+
+// FirmwareURL возвращает ссылку на файл прошивки (<версия>/<файл>), действующую только для станции stationId.
+// lastAttempt - когда станция может начать последнюю попытку скачивания, ссылка действует ещё URLTTL после него
+func (s *Store) FirmwareURL(stationId int, name string, lastAttempt time.Time) (string, error) {
+	name, err := cleanName(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(s.firmwarePath(name)); err != nil {
+		return "", fmt.Errorf("firmware %s: %w", name, err)
+	}
+	return s.signedURL(KindFirmware, stationId, name, lastAttempt)
+}
+
+// DiagnosticsURL возвращает адрес, по которому станция stationId может выгрузить диагностику.
+// lastAttempt - как в FirmwareURL
+func (s *Store) DiagnosticsURL(stationId int, lastAttempt time.Time) (string, error) {
+	return s.signedURL(KindDiagnostics, stationId, "", lastAttempt)
+}
+
+func (s *Store) signedURL(kind string, stationId int, name string, lastAttempt time.Time) (string, error) {
+	if s.baseURL == "" {
+		return "", ErrNoBaseURL
+	}
+	if now := time.Now(); lastAttempt.Before(now) {
+		lastAttempt = now
+	}
+	expires := lastAttempt.Add(s.ttl).Unix()
+	u := fmt.Sprintf("%s/%s/%d/%d/%s/", s.baseURL, kind, stationId, expires, s.sign(kind, stationId, expires, name))
+	return u + name, nil
+}
+
+func (s *Store) sign(kind string, stationId int, expires int64, name string) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s|%d|%d|%s", kind, stationId, expires, name)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify разбирает путь подписанной ссылки (без префикса /<kind>/) и проверяет подпись.
+// Для диагностики имя файла в подпись не входит. Возвращает id станции и имя файла
+func (s *Store) Verify(kind string, urlPath string) (int, string, error) {
+	parts := strings.SplitN(strings.Trim(urlPath, "/"), "/", 4)
+	if len(parts) < 3 {
+		return 0, "", ErrInvalidSignature
+	}
+	stationId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", ErrInvalidSignature
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", ErrInvalidSignature
+	}
+	name := ""
+	if len(parts) == 4 {
+		if name, err = cleanName(parts[3]); err != nil {
+			return 0, "", err
+		}
+	}
+	signed := name
+	if kind == KindDiagnostics {
+		signed = ""
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(kind, stationId, expires, signed))) {
+		return 0, "", ErrInvalidSignature
+	}
+	if time.Now().Unix() > expires {
+		return 0, "", ErrURLExpired
+	}
+	return stationId, name, nil
+}
+
+// OpenFirmware открывает файл прошивки по имени <версия>/<файл>
+func (s *Store) OpenFirmware(name string) (*os.File, error) {
+	name, err := cleanName(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(s.firmwarePath(name))
+}
+
+// SaveDiagnostics сохраняет файл диагностики станции и возвращает его имя в каталоге станции и размер
+func (s *Store) SaveDiagnostics(stationId int, filename string, r io.Reader) (string, int64, error) {
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if filename == "." || filename == "/" || filename == "" {
+		filename = "diagnostics"
+	}
+	dir := filepath.Join(s.dir, KindDiagnostics, strconv.Itoa(stationId))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", 0, err
+	}
+	name, f, err := createUnique(dir, filename)
+	if err != nil {
+		return "", 0, err
+	}
+	n, err := io.Copy(f, io.LimitReader(r, s.maxUploadSize+1))
+	if err == nil && n > s.maxUploadSize {
+		err = ErrTooLarge
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filepath.Join(dir, name))
+		return "", 0, err
+	}
+	return name, n, nil
+}
+
+// createUnique создаёт в dir новый файл <время>_<случайный суффикс>_<filename>. Суффикс и O_EXCL не дают
+// выгрузкам с одинаковым именем в одну секунду перезаписать друг друга
+func createUnique(dir string, filename string) (string, *os.File, error) {
+	suffix := make([]byte, 4)
+	for attempt := 0; ; attempt++ {
+		if _, err := rand.Read(suffix); err != nil {
+			return "", nil, err
+		}
+		name := time.Now().UTC().Format("20060102150405") + "_" + hex.EncodeToString(suffix) + "_" + filename
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			return name, f, nil
+		}
+		if !errors.Is(err, os.ErrExist) || attempt == 2 {
+			return "", nil, err
+		}
+	}
+}
+
+// OpenDiagnostics открывает сохранённый файл диагностики станции
+func (s *Store) OpenDiagnostics(stationId int, name string) (*os.File, error) {
+	if name != path.Base(name) || name == "." || name == ".." {
+		return nil, ErrInvalidPath
+	}
+	return os.Open(filepath.Join(s.dir, KindDiagnostics, strconv.Itoa(stationId), name))
+}
+
+func (s *Store) firmwarePath(name string) string {
+	return filepath.Join(s.dir, KindFirmware, filepath.FromSlash(name))
+}
+
+// cleanName запрещает выход за пределы каталога файлов
+func cleanName(name string) (string, error) {
+	cleaned := path.Clean("/" + name)[1:]
+	if cleaned == "" || cleaned != name {
+		return "", ErrInvalidPath
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,210 @@
+package files
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	return &Store{
+		dir:           t.TempDir(),
+		baseURL:       "https://ocpp.example.com",
+		key:           []byte("test-key"),
+		ttl:           time.Hour,
+		maxUploadSize: 16,
+	}
+}
+
+// urlPath отрезает от ссылки адрес сервера и /<kind>/, как это делает обработчик
+func urlPath(t *testing.T, s *Store, kind string, u string) string {
+	t.Helper()
+	prefix := s.baseURL + "/" + kind + "/"
+	if !strings.HasPrefix(u, prefix) {
+		t.Fatalf("url %s does not start with %s", u, prefix)
+	}
+	return strings.TrimPrefix(u, prefix)
+}
+
+func TestCleanName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "1.2.3/fw.bin", want: "1.2.3/fw.bin"},
+		{name: "fw.bin", want: "fw.bin"},
+		{name: "", wantErr: true},
+		{name: "../secret", wantErr: true},
+		{name: "1.2.3/../../secret", wantErr: true},
+		{name: "/etc/passwd", wantErr: true},
+		{name: "1.2.3//fw.bin", wantErr: true},
+		{name: "1.2.3/fw.bin/", wantErr: true},
+		{name: "./fw.bin", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cleanName(tt.name)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPath) {
+					t.Errorf("cleanName(%q) error = %v, want ErrInvalidPath", tt.name, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("cleanName(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	s := newTestStore(t)
+	if err := os.MkdirAll(filepath.Join(s.dir, KindFirmware, "1.2.3"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, KindFirmware, "1.2.3", "fw.bin"), []byte("fw"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	firmwareURL, err := s.FirmwareURL(7, "1.2.3/fw.bin", time.Time{})
+	if err != nil {
+		t.Fatalf("FirmwareURL: %v", err)
+	}
+	diagnosticsURL, err := s.DiagnosticsURL(7, time.Time{})
+	if err != nil {
+		t.Fatalf("DiagnosticsURL: %v", err)
+	}
+	firmwarePath := urlPath(t, s, KindFirmware, firmwareURL)
+	diagnosticsPath := urlPath(t, s, KindDiagnostics, diagnosticsURL)
+	parts := strings.SplitN(firmwarePath, "/", 4)
+	expired := s.sign(KindFirmware, 7, 1, "1.2.3/fw.bin")
+
+	tests := []struct {
+		name        string
+		kind        string
+		path        string
+		wantStation int
+		wantName    string
+		wantErr     error
+	}{
+		{name: "firmware", kind: KindFirmware, path: firmwarePath, wantStation: 7, wantName: "1.2.3/fw.bin"},
+		{name: "diagnostics with file name", kind: KindDiagnostics, path: diagnosticsPath + "diag.zip", wantStation: 7, wantName: "diag.zip"},
+		{name: "diagnostics without file name", kind: KindDiagnostics, path: diagnosticsPath, wantStation: 7},
+		{name: "other station", kind: KindFirmware, path: "8/" + strings.Join(parts[1:], "/"), wantErr: ErrInvalidSignature},
+		{name: "other file", kind: KindFirmware, path: strings.Join(parts[:3], "/") + "/1.2.3/other.bin", wantErr: ErrInvalidSignature},
+		{name: "other kind", kind: KindDiagnostics, path: firmwarePath, wantErr: ErrInvalidSignature},
+		{name: "extended expiry", kind: KindFirmware, path: parts[0] + "/" + strconv.FormatInt(time.Now().Add(48*time.Hour).Unix(), 10) + "/" + parts[2] + "/" + parts[3], wantErr: ErrInvalidSignature},
+		{name: "expired", kind: KindFirmware, path: "7/1/" + expired + "/1.2.3/fw.bin", wantErr: ErrURLExpired},
+		{name: "path traversal", kind: KindFirmware, path: strings.Join(parts[:3], "/") + "/../secret", wantErr: ErrInvalidPath},
+		{name: "too short", kind: KindFirmware, path: "7/1", wantErr: ErrInvalidSignature},
+		{name: "not a number", kind: KindFirmware, path: "x/1/" + expired, wantErr: ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stationId, name, err := s.Verify(tt.kind, tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (stationId != tt.wantStation || name != tt.wantName) {
+				t.Errorf("Verify() = %d, %q, want %d, %q", stationId, name, tt.wantStation, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestSignedURLExpiry(t *testing.T) {
+	s := newTestStore(t)
+	now := time.Now()
+	tests := []struct {
+		name        string
+		lastAttempt time.Time
+		want        time.Time
+	}{
+		{name: "no retries", lastAttempt: time.Time{}, want: now.Add(s.ttl)},
+		{name: "last attempt in the past", lastAttempt: now.Add(-24 * time.Hour), want: now.Add(s.ttl)},
+		{name: "last attempt in the future", lastAttempt: now.Add(6 * time.Hour), want: now.Add(6*time.Hour + s.ttl)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := s.DiagnosticsURL(1, tt.lastAttempt)
+			if err != nil {
+				t.Fatalf("DiagnosticsURL: %v", err)
+			}
+			expires, err := strconv.ParseInt(strings.Split(urlPath(t, s, KindDiagnostics, u), "/")[1], 10, 64)
+			if err != nil {
+				t.Fatalf("url %s: %v", u, err)
+			}
+			if diff := expires - tt.want.Unix(); diff < -1 || diff > 1 {
+				t.Errorf("expires = %d, want %d", expires, tt.want.Unix())
+			}
+		})
+	}
+}
+
+func TestSignedURLRequiresBaseURL(t *testing.T) {
+	s := newTestStore(t)
+	s.baseURL = ""
+	if _, err := s.DiagnosticsURL(1, time.Time{}); !errors.Is(err, ErrNoBaseURL) {
+		t.Errorf("DiagnosticsURL() error = %v, want ErrNoBaseURL", err)
+	}
+}
+
+func TestSaveDiagnostics(t *testing.T) {
+	s := newTestStore(t)
+	tests := []struct {
+		name     string
+		filename string
+		body     string
+		wantName string
+		wantErr  error
+	}{
+		{name: "plain", filename: "diag.zip", body: "data", wantName: "_diag.zip"},
+		{name: "path in file name", filename: "..\\..\\diag.zip", body: "data", wantName: "_diag.zip"},
+		{name: "empty file name", filename: "", body: "data", wantName: "_diagnostics"},
+		{name: "too large", filename: "big.zip", body: strings.Repeat("x", 17), wantErr: ErrTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, size, err := s.SaveDiagnostics(3, tt.filename, strings.NewReader(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SaveDiagnostics() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !strings.HasSuffix(name, tt.wantName) || size != int64(len(tt.body)) {
+				t.Errorf("SaveDiagnostics() = %q, %d, want suffix %q, %d", name, size, tt.wantName, len(tt.body))
+			}
+			f, err := s.OpenDiagnostics(3, name)
+			if err != nil {
+				t.Fatalf("OpenDiagnostics: %v", err)
+			}
+			f.Close()
+		})
+	}
+	first, _, err := s.SaveDiagnostics(3, "same.zip", strings.NewReader("first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _, err := s.SaveDiagnostics(3, "same.zip", strings.NewReader("second"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Errorf("two uploads of same.zip got the same name %q", first)
+	}
+	entries, err := os.ReadDir(filepath.Join(s.dir, KindDiagnostics, "3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), "big.zip") {
+			t.Errorf("oversized file %s was kept", entry.Name())
+		}
+	}
+}
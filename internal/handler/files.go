@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/files"
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+// FirmwareHandler отдаёт станции файл прошивки по подписанной ссылке
+func (h *Handler) FirmwareHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	stationId, name, err := h.files.Verify(files.KindFirmware, strings.TrimPrefix(r.URL.Path, "/"+files.KindFirmware+"/"))
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	disableDeadlines(w)
+	f, err := h.files.OpenFirmware(name)
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	log.Printf("Станция %d скачивает прошивку %s", stationId, name)
+	http.ServeContent(w, r, path.Base(name), info.ModTime(), f)
+}
+
+// DiagnosticsUploadHandler принимает диагностику от станции: PUT с файлом в теле
+// или POST multipart/form-data с одним или несколькими файлами
+func (h *Handler) DiagnosticsUploadHandler(w http.ResponseWriter, r *http.Request) {
+	stationId, name, err := h.files.Verify(files.KindDiagnostics, strings.TrimPrefix(r.URL.Path, "/"+files.KindDiagnostics+"/"))
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	disableDeadlines(w)
+	r.Body = http.MaxBytesReader(w, r.Body, h.files.MaxUploadSize())
+
+	var saved []*models.DiagnosticsFile
+	switch r.Method {
+	case http.MethodPut:
+		file, err := h.saveDiagnostics(stationId, name, r.Body)
+		if err != nil {
+			writeFileError(w, err)
+			return
+		}
+		saved = append(saved, file)
+	case http.MethodPost:
+		reader, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, "multipart/form-data expected", http.StatusBadRequest)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				writeFileError(w, err)
+				return
+			}
+			if part.FileName() == "" {
+				part.Close()
+				continue
+			}
+			file, err := h.saveDiagnostics(stationId, part.FileName(), part)
+			part.Close()
+			if err != nil {
+				writeFileError(w, err)
+				return
+			}
+			saved = append(saved, file)
+		}
+		if len(saved) == 0 {
+			http.Error(w, "no file in request", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	for _, file := range saved {
+		log.Printf("Станция %d выгрузила диагностику %s (%d байт), запрос %d", stationId, file.Name, file.Size, file.RequestId)
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// saveDiagnostics сохраняет файл и записывает его в базу, привязывая к текущему запросу диагностики станции
+func (h *Handler) saveDiagnostics(stationId int, filename string, body io.Reader) (*models.DiagnosticsFile, error) {
+	name, size, err := h.files.SaveDiagnostics(stationId, filename, body)
+	if err != nil {
+		return nil, err
+	}
+	file := &models.DiagnosticsFile{
+		StationId: stationId,
+		Name:      name,
+		Size:      size,
+		CreatedAt: time.Now().UTC().Add(time.Hour * 3).Format("2006-01-02 15:04:05"),
+	}
+	if request, err := h.repository.Diagnostics.GetActiveDiagnosticsRequest(stationId); err == nil && request != nil {
+		file.RequestId = request.Id
+	}
+	if err := h.repository.Diagnostics.CreateDiagnosticsFile(file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// disableDeadlines снимает ReadTimeout и WriteTimeout сервера для передачи файла по проверенной ссылке:
+// по сотовой связи прошивка и диагностика передаются дольше
+func disableDeadlines(w http.ResponseWriter) {
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(time.Time{}); err != nil {
+		log.Printf("Не удалось снять таймаут чтения: %v", err)
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Не удалось снять таймаут записи: %v", err)
+	}
+}
+
+func writeFileError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, files.ErrInvalidSignature), errors.Is(err, files.ErrURLExpired):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, files.ErrInvalidPath), errors.Is(err, os.ErrNotExist):
+		http.Error(w, "not found", http.StatusNotFound)
+	case errors.Is(err, files.ErrTooLarge), errors.As(err, &maxBytesErr):
+		http.Error(w, "file too large", http.StatusRequestEntityTooLarge)
+	default:
+		log.Printf("Ошибка файлового сервера: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/delevopersmoke/ocpp_microservice/internal/config"
+	"github.com/delevopersmoke/ocpp_microservice/internal/files"
 	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
 	"github.com/delevopersmoke/ocpp_microservice/internal/service"
 	"github.com/gorilla/websocket"
@@ -12,18 +14,21 @@ import (
 
 type Handler struct {
 	repository *repository.Repository
+	cfg        *config.Config
+	files      *files.Store
 }
 
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
+	// Subprotocols не задан: подпротокол выбирает selectSubprotocol и передаёт в заголовке ответа
 	CheckOrigin: func(r *http.Request) bool {
 		return true // Разрешить все соединения (для разработки)
 	},
 }
 
-func NewHandler(repository *repository.Repository) *Handler {
-	return &Handler{repository: repository}
+func NewHandler(repository *repository.Repository, cfg *config.Config, files *files.Store) *Handler {
+	return &Handler{repository: repository, cfg: cfg, files: files}
 }
 
 //go func() {
@@ -34,8 +39,11 @@ func NewHandler(repository *repository.Repository) *Handler {
 //	}
 //}()
 
-func (h *Handler) InitRoutes() {
-	http.HandleFunc("/ws/", h.OCPPWebSocketHandler)
+// InitRoutes регистрирует маршруты станций на mux. Служебные обработчики (метрики) сюда не попадают
+func (h *Handler) InitRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/ws/", h.OCPPWebSocketHandler)
+	mux.HandleFunc("/"+files.KindFirmware+"/", h.FirmwareHandler)
+	mux.HandleFunc("/"+files.KindDiagnostics+"/", h.DiagnosticsUploadHandler)
 }
 
 func (h *Handler) OCPPWebSocketHandler(w http.ResponseWriter, r *http.Request) {
@@ -48,13 +56,55 @@ func (h *Handler) OCPPWebSocketHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	conn, err := upgrader.Upgrade(w, r, nil)
+	subprotocol, ok := selectSubprotocol(r)
+	if !ok {
+		log.Printf("Станция %s запросила неподдерживаемые подпротоколы %v", chargeBoxId, websocket.Subprotocols(r))
+		http.Error(w, "unsupported websocket subprotocol", http.StatusBadRequest)
+		return
+	}
+
+	if h.cfg.DuplicateConnectionPolicy() == config.DuplicateConnectionReject {
+		if _, ok := service.GetStationService(station.Id); ok {
+			log.Printf("Станция %s уже подключена, новое соединение отклонено", chargeBoxId)
+			http.Error(w, "station already connected", http.StatusConflict)
+			return
+		}
+	}
+
+	var responseHeader http.Header
+	if subprotocol != "" {
+		responseHeader = http.Header{"Sec-Websocket-Protocol": {subprotocol}}
+	}
+	conn, err := upgrader.Upgrade(w, r, responseHeader)
 	if err != nil {
 		log.Println("Ошибка апгрейда WebSocket:", err)
 		return
 	}
 
-	stationService := service.NewStationService(conn, h.repository, station.Id)
-	go stationService.HandleStationConnection()
+	stationService, err := service.NewStationService(conn, h.repository, h.cfg, station.Id)
+	if err != nil {
+		log.Printf("Станция %s: %v, соединение закрыто", chargeBoxId, err)
+		return
+	}
+	// Регистрируем сервис до запуска цикла чтения, чтобы при быстром отключении он был удалён из map.
+	// Старое соединение той же станции при этом закрывается
 	service.AddStationService(station.Id, stationService)
+	stationService.SetOnline()
+	go stationService.HandleStationConnection()
+}
+
+// selectSubprotocol выбирает подпротокол из Sec-WebSocket-Protocol. Станция перечисляет версии
+// в порядке предпочтения, выбирается первая, для которой есть обработчики. Станции, не передающие
+// заголовок, считаются OCPP 1.6J, для них возвращается пустой подпротокол
+func selectSubprotocol(r *http.Request) (string, bool) {
+	requested := websocket.Subprotocols(r)
+	if len(requested) == 0 {
+		return "", true
+	}
+	for _, protocol := range requested {
+		if service.SupportsOcppVersion(protocol) {
+			return protocol, true
+		}
+	}
+	return "", false
 }
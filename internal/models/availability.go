@@ -0,0 +1,15 @@
+package models
+
+// Желаемая доступность в station_availability.availability
+const (
+	AvailabilityOperative   = "Operative"
+	AvailabilityInoperative = "Inoperative"
+)
+
+// Availability - желаемая доступность станции (ConnectorId = 0) или коннектора (ocpp_id)
+type Availability struct {
+	StationId    int    `json:"station_id"`
+	ConnectorId  int    `json:"connector_id"`
+	Availability string `json:"availability"`
+	UpdatedAt    string `json:"updated_at"`
+}
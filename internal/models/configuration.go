@@ -0,0 +1,12 @@
+package models
+
+// ConfigurationKey - последнее известное значение ключа конфигурации станции.
+// RebootRequired - значение принято станцией, но вступит в силу после перезагрузки
+type ConfigurationKey struct {
+	StationId      int    `json:"station_id"`
+	Key            string `json:"key"`
+	Value          string `json:"value"`
+	Readonly       bool   `json:"readonly"`
+	RebootRequired bool   `json:"reboot_required"`
+	UpdatedAt      string `json:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+// Состояния запроса диагностики. uploading, uploaded и upload_failed переводятся
+// из DiagnosticsStatusNotification (в 2.0.1 - из LogStatusNotification),
+// failed - команду не удалось отправить или станция её отклонила
+const (
+	DiagnosticsRequested    = "requested"
+	DiagnosticsNoFile       = "no_file"
+	DiagnosticsFailed       = "failed"
+	DiagnosticsUploading    = "uploading"
+	DiagnosticsUploaded     = "uploaded"
+	DiagnosticsUploadFailed = "upload_failed"
+)
+
+type DiagnosticsRequest struct {
+	Id            int    `json:"id"`
+	StationId     int    `json:"station_id"`
+	Location      string `json:"location"`
+	StartTime     string `json:"start_time"`
+	StopTime      string `json:"stop_time"`
+	Retries       int    `json:"retries"`
+	RetryInterval int    `json:"retry_interval"`
+	Status        string `json:"status"`
+	// FileName - имя файла, которое станция сообщила в ответе на GetDiagnostics
+	FileName  string `json:"file_name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// DiagnosticsFile - файл диагностики, выгруженный станцией на встроенный сервер
+type DiagnosticsFile struct {
+	Id        int    `json:"id"`
+	StationId int    `json:"station_id"`
+	RequestId int    `json:"request_id"`
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedAt string `json:"created_at"`
+}
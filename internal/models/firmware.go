@@ -0,0 +1,54 @@
+package models
+
+// Состояния кампании обновления прошивки
+const (
+	FirmwareCampaignRunning   = "running"
+	FirmwareCampaignPaused    = "paused"
+	FirmwareCampaignCompleted = "completed"
+)
+
+// Состояния станции в кампании. Промежуточные состояния совпадают со статусами
+// FirmwareStatusNotification, verified - станция загрузилась с целевой версией
+const (
+	FirmwareStationPending     = "pending"
+	FirmwareStationSent        = "sent"
+	FirmwareStationDownloading = "Downloading"
+	FirmwareStationDownloaded  = "Downloaded"
+	FirmwareStationInstalling  = "Installing"
+	FirmwareStationInstalled   = "Installed"
+	FirmwareStationVerified    = "verified"
+	FirmwareStationFailed      = "failed"
+)
+
+type FirmwareCampaign struct {
+	Id            int    `json:"id"`
+	FirmwareUrl   string `json:"firmware_url"`
+	TargetVersion string `json:"target_version"`
+	// RetrieveDate - не раньше какого момента станции скачивают прошивку, пусто - сразу
+	RetrieveDate string `json:"retrieve_date"`
+	WaveSize     int    `json:"wave_size"`
+	CurrentWave  int    `json:"current_wave"`
+	// FailureThreshold - сколько станций может завершиться ошибкой, прежде чем кампания встанет на паузу
+	FailureThreshold int `json:"failure_threshold"`
+	// ResumedFailures - ошибки, учтённые при последнем возобновлении. Порог применяется к ошибкам сверх них
+	ResumedFailures int `json:"resumed_failures"`
+	// StationTimeout - сколько секунд станция может не менять состояние, прежде чем будет считаться неудачной
+	StationTimeout int    `json:"station_timeout"`
+	Status         string `json:"status"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+type FirmwareCampaignStation struct {
+	CampaignId int    `json:"campaign_id"`
+	StationId  int    `json:"station_id"`
+	Wave       int    `json:"wave"`
+	Status     string `json:"status"`
+	Error      string `json:"error"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// Finished сообщает, что станция закончила участие в кампании
+func (s *FirmwareCampaignStation) Finished() bool {
+	return s.Status == FirmwareStationVerified || s.Status == FirmwareStationFailed
+}
@@ -0,0 +1,39 @@
+package models
+
+// Статусы idTag, совпадают со статусами idTagInfo OCPP
+const (
+	IdTagAccepted = "Accepted"
+	IdTagBlocked  = "Blocked"
+	IdTagExpired  = "Expired"
+	IdTagInvalid  = "Invalid"
+)
+
+// IdTag - RFID-карта или другой идентификатор пользователя.
+// Version - версия локального списка авторизации, в которой запись изменилась последний раз
+type IdTag struct {
+	IdTag       string `json:"id_tag"`
+	UserId      int    `json:"user_id"`
+	ParentIdTag string `json:"parent_id_tag"`
+	Status      string `json:"status"`
+	ExpiryDate  string `json:"expiry_date"`
+	Version     int    `json:"version"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// IdTagAccess разрешает idTag станцию StationId или все станции локации LocationId.
+// idTag без таких записей действует на всех станциях
+type IdTagAccess struct {
+	IdTag      string `json:"id_tag"`
+	StationId  int    `json:"station_id"`
+	LocationId int    `json:"location_id"`
+}
+
+// IdTagGroup - ограничения группы карт с общим parentIdTag, 0 - без ограничения.
+// Станции и локации группы задаются записями IdTagAccess для ParentIdTag
+type IdTagGroup struct {
+	ParentIdTag           string  `json:"parent_id_tag"`
+	Name                  string  `json:"name"`
+	MaxConcurrentSessions int     `json:"max_concurrent_sessions"`
+	MonthlyEnergyKwh      float64 `json:"monthly_energy_kwh"`
+	UpdatedAt             string  `json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// RejectedTransaction - транзакция, которую станция начала, а сервер отклонил в StartTransaction.
+// Id передаётся станции как transactionId. Status - статус idTagInfo ответа, время - в формате базы
+type RejectedTransaction struct {
+	Id          int    `json:"id"`
+	StationId   int    `json:"station_id"`
+	ConnectorId int    `json:"connector_id"`
+	IdTag       string `json:"id_tag"`
+	Status      string `json:"status"`
+	MeterStart  int    `json:"meter_start"`
+	// MeterStop - показание из StopTransaction в Вт·ч, nil - станция транзакцию ещё не завершила
+	MeterStop *int   `json:"meter_stop"`
+	StartedAt string `json:"started_at"`
+	StoppedAt string `json:"stopped_at"`
+	CreatedAt string `json:"created_at"`
+}
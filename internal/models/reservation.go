@@ -0,0 +1,25 @@
+package models
+
+// Состояния резервирования. rejected - станция не приняла ReserveNow
+const (
+	ReservationActive    = "active"
+	ReservationUsed      = "used"
+	ReservationCancelled = "cancelled"
+	ReservationExpired   = "expired"
+	ReservationRejected  = "rejected"
+)
+
+// Reservation - резервирование коннектора (ocpp_id, 0 - любой коннектор станции) за idTag
+type Reservation struct {
+	Id          int    `json:"id"`
+	StationId   int    `json:"station_id"`
+	ConnectorId int    `json:"connector_id"`
+	IdTag       string `json:"id_tag"`
+	ParentIdTag string `json:"parent_id_tag"`
+	ExpiryDate  string `json:"expiry_date"`
+	Status      string `json:"status"`
+	// SessionId - сессия, начатая по резервированию
+	SessionId int    `json:"session_id"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
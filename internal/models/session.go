@@ -36,4 +36,11 @@ type Session struct {
 	TimeLeft            int
 	LocationPhotoUrl    string
 	Owner               string
+	// TransactionId - идентификатор транзакции, выданный станцией OCPP 2.0.1
+	TransactionId string
+	// ParentIdTag - группа карты, начавшей сессию, пусто для карт без группы
+	ParentIdTag string
+	// MeterStart - показание счётчика в начале транзакции OCPP 2.0.1 в кВт·ч, ChargedEnergy считается от него.
+	// nil - показание неизвестно (OCPP 1.6 или станция его не прислала)
+	MeterStart *float64
 }
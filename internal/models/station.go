@@ -1,5 +1,17 @@
 package models
 
+// Состояния станции в stations.state
+const (
+	StationStateOnline  = "online"
+	StationStateOffline = "offline"
+)
+
+// Версии OCPP, согласуемые через Sec-WebSocket-Protocol
+const (
+	OcppVersion16  = "ocpp1.6"
+	OcppVersion201 = "ocpp2.0.1"
+)
+
 type Station struct {
 	Id                int    `json:"id"`
 	ChargeBoxId       string `json:"charge_box_id"`
@@ -8,4 +20,5 @@ type Station struct {
 	ChargeBoxModel    string `json:"charge_box_model"`
 	ChargeBoxFirmware string `json:"charge_box_firmware"`
 	State             string `json:"state"`
+	OcppVersion       string `json:"ocpp_version"`
 }
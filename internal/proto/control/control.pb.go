@@ -24,11 +24,17 @@ const (
 type ErrorCode int32
 
 const (
-	ErrorCode_errorUnknown          ErrorCode = 0
-	ErrorCode_errorDB               ErrorCode = 1
-	ErrorCode_stationNotConnected   ErrorCode = 902
-	ErrorCode_sendCommandError      ErrorCode = 903
-	ErrorCode_commandWasNotAccepted ErrorCode = 904
+	ErrorCode_errorUnknown              ErrorCode = 0
+	ErrorCode_errorDB                   ErrorCode = 1
+	ErrorCode_stationNotConnected       ErrorCode = 902
+	ErrorCode_sendCommandError          ErrorCode = 903
+	ErrorCode_commandWasNotAccepted     ErrorCode = 904
+	ErrorCode_commandCallError          ErrorCode = 905
+	ErrorCode_bootNotificationTimeout   ErrorCode = 906
+	ErrorCode_commandNotSupported       ErrorCode = 907
+	ErrorCode_connectorHasActiveSession ErrorCode = 908
+	ErrorCode_notFound                  ErrorCode = 909
+	ErrorCode_invalidArgument           ErrorCode = 910
 )
 
 // Enum value maps for ErrorCode.
@@ -39,13 +45,25 @@ var (
 		902: "stationNotConnected",
 		903: "sendCommandError",
 		904: "commandWasNotAccepted",
+		905: "commandCallError",
+		906: "bootNotificationTimeout",
+		907: "commandNotSupported",
+		908: "connectorHasActiveSession",
+		909: "notFound",
+		910: "invalidArgument",
 	}
 	ErrorCode_value = map[string]int32{
-		"errorUnknown":          0,
-		"errorDB":               1,
-		"stationNotConnected":   902,
-		"sendCommandError":      903,
-		"commandWasNotAccepted": 904,
+		"errorUnknown":              0,
+		"errorDB":                   1,
+		"stationNotConnected":       902,
+		"sendCommandError":          903,
+		"commandWasNotAccepted":     904,
+		"commandCallError":          905,
+		"bootNotificationTimeout":   906,
+		"commandNotSupported":       907,
+		"connectorHasActiveSession": 908,
+		"notFound":                  909,
+		"invalidArgument":           910,
 	}
 )
 
@@ -76,6 +94,156 @@ func (ErrorCode) EnumDescriptor() ([]byte, []int) {
 	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{0}
 }
 
+type ResetType int32
+
+const (
+	ResetType_soft ResetType = 0
+	ResetType_hard ResetType = 1
+)
+
+// Enum value maps for ResetType.
+var (
+	ResetType_name = map[int32]string{
+		0: "soft",
+		1: "hard",
+	}
+	ResetType_value = map[string]int32{
+		"soft": 0,
+		"hard": 1,
+	}
+)
+
+func (x ResetType) Enum() *ResetType {
+	p := new(ResetType)
+	*p = x
+	return p
+}
+
+func (x ResetType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResetType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_control_control_proto_enumTypes[1].Descriptor()
+}
+
+func (ResetType) Type() protoreflect.EnumType {
+	return &file_internal_proto_control_control_proto_enumTypes[1]
+}
+
+func (x ResetType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResetType.Descriptor instead.
+func (ResetType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{1}
+}
+
+type AvailabilityType int32
+
+const (
+	AvailabilityType_operative   AvailabilityType = 0
+	AvailabilityType_inoperative AvailabilityType = 1
+)
+
+// Enum value maps for AvailabilityType.
+var (
+	AvailabilityType_name = map[int32]string{
+		0: "operative",
+		1: "inoperative",
+	}
+	AvailabilityType_value = map[string]int32{
+		"operative":   0,
+		"inoperative": 1,
+	}
+)
+
+func (x AvailabilityType) Enum() *AvailabilityType {
+	p := new(AvailabilityType)
+	*p = x
+	return p
+}
+
+func (x AvailabilityType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AvailabilityType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_control_control_proto_enumTypes[2].Descriptor()
+}
+
+func (AvailabilityType) Type() protoreflect.EnumType {
+	return &file_internal_proto_control_control_proto_enumTypes[2]
+}
+
+func (x AvailabilityType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AvailabilityType.Descriptor instead.
+func (AvailabilityType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{2}
+}
+
+type TriggerMessageType int32
+
+const (
+	TriggerMessageType_statusNotification            TriggerMessageType = 0
+	TriggerMessageType_meterValues                   TriggerMessageType = 1
+	TriggerMessageType_heartbeat                     TriggerMessageType = 2
+	TriggerMessageType_bootNotification              TriggerMessageType = 3
+	TriggerMessageType_diagnosticsStatusNotification TriggerMessageType = 4
+	TriggerMessageType_firmwareStatusNotification    TriggerMessageType = 5
+)
+
+// Enum value maps for TriggerMessageType.
+var (
+	TriggerMessageType_name = map[int32]string{
+		0: "statusNotification",
+		1: "meterValues",
+		2: "heartbeat",
+		3: "bootNotification",
+		4: "diagnosticsStatusNotification",
+		5: "firmwareStatusNotification",
+	}
+	TriggerMessageType_value = map[string]int32{
+		"statusNotification":            0,
+		"meterValues":                   1,
+		"heartbeat":                     2,
+		"bootNotification":              3,
+		"diagnosticsStatusNotification": 4,
+		"firmwareStatusNotification":    5,
+	}
+)
+
+func (x TriggerMessageType) Enum() *TriggerMessageType {
+	p := new(TriggerMessageType)
+	*p = x
+	return p
+}
+
+func (x TriggerMessageType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TriggerMessageType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_control_control_proto_enumTypes[3].Descriptor()
+}
+
+func (TriggerMessageType) Type() protoreflect.EnumType {
+	return &file_internal_proto_control_control_proto_enumTypes[3]
+}
+
+func (x TriggerMessageType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TriggerMessageType.Descriptor instead.
+func (TriggerMessageType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{3}
+}
+
 type CustomErrorDetail struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int64                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -128,6 +296,67 @@ func (x *CustomErrorDetail) GetError() string {
 	return ""
 }
 
+// OcppErrorDetail передаёт содержимое CALLERROR, полученного от станции
+type OcppErrorDetail struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ErrorCode        string                 `protobuf:"bytes,1,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDescription string                 `protobuf:"bytes,2,opt,name=error_description,json=errorDescription,proto3" json:"error_description,omitempty"`
+	ErrorDetails     string                 `protobuf:"bytes,3,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OcppErrorDetail) Reset() {
+	*x = OcppErrorDetail{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OcppErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OcppErrorDetail) ProtoMessage() {}
+
+func (x *OcppErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OcppErrorDetail.ProtoReflect.Descriptor instead.
+func (*OcppErrorDetail) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OcppErrorDetail) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *OcppErrorDetail) GetErrorDescription() string {
+	if x != nil {
+		return x.ErrorDescription
+	}
+	return ""
+}
+
+func (x *OcppErrorDetail) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
 type CommandResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -138,7 +367,7 @@ type CommandResponse struct {
 
 func (x *CommandResponse) Reset() {
 	*x = CommandResponse{}
-	mi := &file_internal_proto_control_control_proto_msgTypes[1]
+	mi := &file_internal_proto_control_control_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -150,7 +379,7 @@ func (x *CommandResponse) String() string {
 func (*CommandResponse) ProtoMessage() {}
 
 func (x *CommandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_control_control_proto_msgTypes[1]
+	mi := &file_internal_proto_control_control_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -163,7 +392,7 @@ func (x *CommandResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandResponse.ProtoReflect.Descriptor instead.
 func (*CommandResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{1}
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CommandResponse) GetSuccess() bool {
@@ -191,7 +420,7 @@ type StartStationRequest struct {
 
 func (x *StartStationRequest) Reset() {
 	*x = StartStationRequest{}
-	mi := &file_internal_proto_control_control_proto_msgTypes[2]
+	mi := &file_internal_proto_control_control_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -203,7 +432,7 @@ func (x *StartStationRequest) String() string {
 func (*StartStationRequest) ProtoMessage() {}
 
 func (x *StartStationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_control_control_proto_msgTypes[2]
+	mi := &file_internal_proto_control_control_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,7 +445,7 @@ func (x *StartStationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartStationRequest.ProtoReflect.Descriptor instead.
 func (*StartStationRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{2}
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *StartStationRequest) GetStationId() int64 {
@@ -249,7 +478,7 @@ type StartStationResponse struct {
 
 func (x *StartStationResponse) Reset() {
 	*x = StartStationResponse{}
-	mi := &file_internal_proto_control_control_proto_msgTypes[3]
+	mi := &file_internal_proto_control_control_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -261,7 +490,7 @@ func (x *StartStationResponse) String() string {
 func (*StartStationResponse) ProtoMessage() {}
 
 func (x *StartStationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_control_control_proto_msgTypes[3]
+	mi := &file_internal_proto_control_control_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -274,7 +503,7 @@ func (x *StartStationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartStationResponse.ProtoReflect.Descriptor instead.
 func (*StartStationResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{3}
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *StartStationResponse) GetSuccess() bool {
@@ -294,7 +523,7 @@ type StopStationRequest struct {
 
 func (x *StopStationRequest) Reset() {
 	*x = StopStationRequest{}
-	mi := &file_internal_proto_control_control_proto_msgTypes[4]
+	mi := &file_internal_proto_control_control_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -306,7 +535,7 @@ func (x *StopStationRequest) String() string {
 func (*StopStationRequest) ProtoMessage() {}
 
 func (x *StopStationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_control_control_proto_msgTypes[4]
+	mi := &file_internal_proto_control_control_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,7 +548,7 @@ func (x *StopStationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopStationRequest.ProtoReflect.Descriptor instead.
 func (*StopStationRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{4}
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *StopStationRequest) GetStationId() int64 {
@@ -345,7 +574,7 @@ type StopStationResponse struct {
 
 func (x *StopStationResponse) Reset() {
 	*x = StopStationResponse{}
-	mi := &file_internal_proto_control_control_proto_msgTypes[5]
+	mi := &file_internal_proto_control_control_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -357,7 +586,7 @@ func (x *StopStationResponse) String() string {
 func (*StopStationResponse) ProtoMessage() {}
 
 func (x *StopStationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_control_control_proto_msgTypes[5]
+	mi := &file_internal_proto_control_control_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,7 +599,7 @@ func (x *StopStationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopStationResponse.ProtoReflect.Descriptor instead.
 func (*StopStationResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{5}
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *StopStationResponse) GetSuccess() bool {
@@ -380,41 +609,3847 @@ func (x *StopStationResponse) GetSuccess() bool {
 	return false
 }
 
-var File_internal_proto_control_control_proto protoreflect.FileDescriptor
+// ResetStationRequest перезагружает станцию. При wait_for_boot ответ приходит только
+// после BootNotification от перезагруженной станции (не дольше boot_timeout_seconds, по умолчанию 120)
+type ResetStationRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	StationId          int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Type               ResetType              `protobuf:"varint,2,opt,name=type,proto3,enum=command.ResetType" json:"type,omitempty"`
+	WaitForBoot        bool                   `protobuf:"varint,3,opt,name=wait_for_boot,json=waitForBoot,proto3" json:"wait_for_boot,omitempty"`
+	BootTimeoutSeconds int64                  `protobuf:"varint,4,opt,name=boot_timeout_seconds,json=bootTimeoutSeconds,proto3" json:"boot_timeout_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
 
-const file_internal_proto_control_control_proto_rawDesc = "" +
-	"\n" +
-	"$internal/proto/control/control.proto\x12\acommand\"=\n" +
-	"\x11CustomErrorDetail\x12\x12\n" +
-	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"E\n" +
-	"\x0fCommandResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"v\n" +
-	"\x13StartStationRequest\x12\x1d\n" +
-	"\n" +
-	"station_id\x18\x01 \x01(\x03R\tstationId\x12!\n" +
-	"\fconnector_id\x18\x02 \x01(\x03R\vconnectorId\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x03 \x01(\x03R\tsessionId\"0\n" +
-	"\x14StartStationResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"R\n" +
-	"\x12StopStationRequest\x12\x1d\n" +
-	"\n" +
-	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x02 \x01(\x03R\tsessionId\"/\n" +
-	"\x13StopStationResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess*w\n" +
-	"\tErrorCode\x12\x10\n" +
-	"\ferrorUnknown\x10\x00\x12\v\n" +
-	"\aerrorDB\x10\x01\x12\x18\n" +
-	"\x13stationNotConnected\x10\x86\a\x12\x15\n" +
-	"\x10sendCommandError\x10\x87\a\x12\x1a\n" +
-	"\x15commandWasNotAccepted\x10\x88\a2\x99\x01\n" +
-	"\x0eControlService\x12D\n" +
-	"\x05Start\x12\x1c.command.StartStationRequest\x1a\x1d.command.StartStationResponse\x12A\n" +
-	"\x04Stop\x12\x1b.command.StopStationRequest\x1a\x1c.command.StopStationResponseB\vZ\t.;controlb\x06proto3"
+func (x *ResetStationRequest) Reset() {
+	*x = ResetStationRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetStationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetStationRequest) ProtoMessage() {}
+
+func (x *ResetStationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetStationRequest.ProtoReflect.Descriptor instead.
+func (*ResetStationRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResetStationRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *ResetStationRequest) GetType() ResetType {
+	if x != nil {
+		return x.Type
+	}
+	return ResetType_soft
+}
+
+func (x *ResetStationRequest) GetWaitForBoot() bool {
+	if x != nil {
+		return x.WaitForBoot
+	}
+	return false
+}
+
+func (x *ResetStationRequest) GetBootTimeoutSeconds() int64 {
+	if x != nil {
+		return x.BootTimeoutSeconds
+	}
+	return 0
+}
+
+type ResetStationResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// status - статус из ответа станции: Accepted, Rejected (2.0.1 - также Scheduled)
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Booted        bool   `protobuf:"varint,3,opt,name=booted,proto3" json:"booted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetStationResponse) Reset() {
+	*x = ResetStationResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetStationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetStationResponse) ProtoMessage() {}
+
+func (x *ResetStationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetStationResponse.ProtoReflect.Descriptor instead.
+func (*ResetStationResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ResetStationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResetStationResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ResetStationResponse) GetBooted() bool {
+	if x != nil {
+		return x.Booted
+	}
+	return false
+}
+
+// ChangeAvailabilityRequest меняет доступность станции (connector_id = 0) или коннектора по ocpp_id.
+// Желаемая доступность сохраняется до отправки команды и повторно применяется после каждой загрузки станции
+type ChangeAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	ConnectorId   int64                  `protobuf:"varint,2,opt,name=connector_id,json=connectorId,proto3" json:"connector_id,omitempty"`
+	Type          AvailabilityType       `protobuf:"varint,3,opt,name=type,proto3,enum=command.AvailabilityType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeAvailabilityRequest) Reset() {
+	*x = ChangeAvailabilityRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeAvailabilityRequest) ProtoMessage() {}
+
+func (x *ChangeAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*ChangeAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ChangeAvailabilityRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *ChangeAvailabilityRequest) GetConnectorId() int64 {
+	if x != nil {
+		return x.ConnectorId
+	}
+	return 0
+}
+
+func (x *ChangeAvailabilityRequest) GetType() AvailabilityType {
+	if x != nil {
+		return x.Type
+	}
+	return AvailabilityType_operative
+}
+
+type ChangeAvailabilityResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// status - Accepted или Scheduled (изменение применится после завершения текущей транзакции)
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeAvailabilityResponse) Reset() {
+	*x = ChangeAvailabilityResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeAvailabilityResponse) ProtoMessage() {}
+
+func (x *ChangeAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*ChangeAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChangeAvailabilityResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ChangeAvailabilityResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ConfigurationKey struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Key            string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value          string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Readonly       bool                   `protobuf:"varint,3,opt,name=readonly,proto3" json:"readonly,omitempty"`
+	RebootRequired bool                   `protobuf:"varint,4,opt,name=reboot_required,json=rebootRequired,proto3" json:"reboot_required,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConfigurationKey) Reset() {
+	*x = ConfigurationKey{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurationKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurationKey) ProtoMessage() {}
+
+func (x *ConfigurationKey) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigurationKey.ProtoReflect.Descriptor instead.
+func (*ConfigurationKey) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ConfigurationKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigurationKey) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ConfigurationKey) GetReadonly() bool {
+	if x != nil {
+		return x.Readonly
+	}
+	return false
+}
+
+func (x *ConfigurationKey) GetRebootRequired() bool {
+	if x != nil {
+		return x.RebootRequired
+	}
+	return false
+}
+
+func (x *ConfigurationKey) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// GetConfigurationRequest запрашивает конфигурацию у станции и обновляет сохранённый снимок.
+// Пустой keys - все ключи. При cached = true возвращается сохранённый снимок без обращения к станции
+type GetConfigurationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Keys          []string               `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Cached        bool                   `protobuf:"varint,3,opt,name=cached,proto3" json:"cached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigurationRequest) Reset() {
+	*x = GetConfigurationRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigurationRequest) ProtoMessage() {}
+
+func (x *GetConfigurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigurationRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigurationRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetConfigurationRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *GetConfigurationRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *GetConfigurationRequest) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type GetConfigurationResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ConfigurationKeys []*ConfigurationKey    `protobuf:"bytes,1,rep,name=configuration_keys,json=configurationKeys,proto3" json:"configuration_keys,omitempty"`
+	UnknownKeys       []string               `protobuf:"bytes,2,rep,name=unknown_keys,json=unknownKeys,proto3" json:"unknown_keys,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetConfigurationResponse) Reset() {
+	*x = GetConfigurationResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigurationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigurationResponse) ProtoMessage() {}
+
+func (x *GetConfigurationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigurationResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigurationResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetConfigurationResponse) GetConfigurationKeys() []*ConfigurationKey {
+	if x != nil {
+		return x.ConfigurationKeys
+	}
+	return nil
+}
+
+func (x *GetConfigurationResponse) GetUnknownKeys() []string {
+	if x != nil {
+		return x.UnknownKeys
+	}
+	return nil
+}
+
+type ChangeConfigurationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeConfigurationRequest) Reset() {
+	*x = ChangeConfigurationRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeConfigurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeConfigurationRequest) ProtoMessage() {}
+
+func (x *ChangeConfigurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeConfigurationRequest.ProtoReflect.Descriptor instead.
+func (*ChangeConfigurationRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChangeConfigurationRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *ChangeConfigurationRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ChangeConfigurationRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ChangeConfigurationResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// status - Accepted или RebootRequired. Rejected и NotSupported возвращаются ошибкой
+	Status         string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	RebootRequired bool   `protobuf:"varint,3,opt,name=reboot_required,json=rebootRequired,proto3" json:"reboot_required,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChangeConfigurationResponse) Reset() {
+	*x = ChangeConfigurationResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeConfigurationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeConfigurationResponse) ProtoMessage() {}
+
+func (x *ChangeConfigurationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeConfigurationResponse.ProtoReflect.Descriptor instead.
+func (*ChangeConfigurationResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChangeConfigurationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ChangeConfigurationResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChangeConfigurationResponse) GetRebootRequired() bool {
+	if x != nil {
+		return x.RebootRequired
+	}
+	return false
+}
+
+// GetConfigurationDriftRequest сравнивает сохранённый снимок конфигурации станции
+// с профилем её производителя и модели
+type GetConfigurationDriftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigurationDriftRequest) Reset() {
+	*x = GetConfigurationDriftRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigurationDriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigurationDriftRequest) ProtoMessage() {}
+
+func (x *GetConfigurationDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigurationDriftRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigurationDriftRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetConfigurationDriftRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+type ConfigurationDrift struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Key      string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Expected string                 `protobuf:"bytes,2,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual   string                 `protobuf:"bytes,3,opt,name=actual,proto3" json:"actual,omitempty"`
+	// state - different, missing (ключа нет в сохранённом снимке), readonly или reboot_required
+	State         string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigurationDrift) Reset() {
+	*x = ConfigurationDrift{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurationDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurationDrift) ProtoMessage() {}
+
+func (x *ConfigurationDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigurationDrift.ProtoReflect.Descriptor instead.
+func (*ConfigurationDrift) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConfigurationDrift) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigurationDrift) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *ConfigurationDrift) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+func (x *ConfigurationDrift) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type GetConfigurationDriftResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        string                 `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Drift         []*ConfigurationDrift  `protobuf:"bytes,3,rep,name=drift,proto3" json:"drift,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigurationDriftResponse) Reset() {
+	*x = GetConfigurationDriftResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigurationDriftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigurationDriftResponse) ProtoMessage() {}
+
+func (x *GetConfigurationDriftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigurationDriftResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigurationDriftResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetConfigurationDriftResponse) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *GetConfigurationDriftResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GetConfigurationDriftResponse) GetDrift() []*ConfigurationDrift {
+	if x != nil {
+		return x.Drift
+	}
+	return nil
+}
+
+// UnlockConnectorRequest разблокирует коннектор по ocpp_id. Если на коннекторе идёт зарядка,
+// команда отклоняется, а при stop_transaction = true сначала останавливается транзакция
+type UnlockConnectorRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	StationId       int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	ConnectorId     int64                  `protobuf:"varint,2,opt,name=connector_id,json=connectorId,proto3" json:"connector_id,omitempty"`
+	StopTransaction bool                   `protobuf:"varint,3,opt,name=stop_transaction,json=stopTransaction,proto3" json:"stop_transaction,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UnlockConnectorRequest) Reset() {
+	*x = UnlockConnectorRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockConnectorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockConnectorRequest) ProtoMessage() {}
+
+func (x *UnlockConnectorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockConnectorRequest.ProtoReflect.Descriptor instead.
+func (*UnlockConnectorRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UnlockConnectorRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *UnlockConnectorRequest) GetConnectorId() int64 {
+	if x != nil {
+		return x.ConnectorId
+	}
+	return 0
+}
+
+func (x *UnlockConnectorRequest) GetStopTransaction() bool {
+	if x != nil {
+		return x.StopTransaction
+	}
+	return false
+}
+
+type UnlockConnectorResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// status - Unlocked. UnlockFailed и NotSupported возвращаются ошибкой
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockConnectorResponse) Reset() {
+	*x = UnlockConnectorResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockConnectorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockConnectorResponse) ProtoMessage() {}
+
+func (x *UnlockConnectorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockConnectorResponse.ProtoReflect.Descriptor instead.
+func (*UnlockConnectorResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UnlockConnectorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UnlockConnectorResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// TriggerMessageRequest просит станцию отправить сообщение. connector_id = 0 - для всех коннекторов
+type TriggerMessageRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	StationId        int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	RequestedMessage TriggerMessageType     `protobuf:"varint,2,opt,name=requested_message,json=requestedMessage,proto3,enum=command.TriggerMessageType" json:"requested_message,omitempty"`
+	ConnectorId      int64                  `protobuf:"varint,3,opt,name=connector_id,json=connectorId,proto3" json:"connector_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TriggerMessageRequest) Reset() {
+	*x = TriggerMessageRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerMessageRequest) ProtoMessage() {}
+
+func (x *TriggerMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerMessageRequest.ProtoReflect.Descriptor instead.
+func (*TriggerMessageRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TriggerMessageRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *TriggerMessageRequest) GetRequestedMessage() TriggerMessageType {
+	if x != nil {
+		return x.RequestedMessage
+	}
+	return TriggerMessageType_statusNotification
+}
+
+func (x *TriggerMessageRequest) GetConnectorId() int64 {
+	if x != nil {
+		return x.ConnectorId
+	}
+	return 0
+}
+
+type TriggerMessageResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// status - Accepted. Rejected и NotImplemented возвращаются ошибкой
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerMessageResponse) Reset() {
+	*x = TriggerMessageResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerMessageResponse) ProtoMessage() {}
+
+func (x *TriggerMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerMessageResponse.ProtoReflect.Descriptor instead.
+func (*TriggerMessageResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TriggerMessageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TriggerMessageResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// UpdateFirmwareRequest отправляет станции UpdateFirmware вне кампании. location - внешний URL
+// или файл встроенного сервера <версия>/<файл>, для которого станции выдаётся подписанная ссылка.
+// retrieve_date в RFC3339, пусто - скачать сразу
+type UpdateFirmwareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	RetrieveDate  string                 `protobuf:"bytes,3,opt,name=retrieve_date,json=retrieveDate,proto3" json:"retrieve_date,omitempty"`
+	Retries       int64                  `protobuf:"varint,4,opt,name=retries,proto3" json:"retries,omitempty"`
+	RetryInterval int64                  `protobuf:"varint,5,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFirmwareRequest) Reset() {
+	*x = UpdateFirmwareRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFirmwareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFirmwareRequest) ProtoMessage() {}
+
+func (x *UpdateFirmwareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFirmwareRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFirmwareRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateFirmwareRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *UpdateFirmwareRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *UpdateFirmwareRequest) GetRetrieveDate() string {
+	if x != nil {
+		return x.RetrieveDate
+	}
+	return ""
+}
+
+func (x *UpdateFirmwareRequest) GetRetries() int64 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *UpdateFirmwareRequest) GetRetryInterval() int64 {
+	if x != nil {
+		return x.RetryInterval
+	}
+	return 0
+}
+
+type UpdateFirmwareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFirmwareResponse) Reset() {
+	*x = UpdateFirmwareResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFirmwareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFirmwareResponse) ProtoMessage() {}
+
+func (x *UpdateFirmwareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFirmwareResponse.ProtoReflect.Descriptor instead.
+func (*UpdateFirmwareResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateFirmwareResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// CreateFirmwareCampaignRequest раскатывает прошивку на станции волнами по wave_size станций.
+// Следующая волна начинается, когда все станции текущей загрузились с target_version или завершились ошибкой.
+// Кампания встаёт на паузу, когда ошибок больше failure_threshold. firmware_url задаётся так же, как location в UpdateFirmware
+type CreateFirmwareCampaignRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	FirmwareUrl           string                 `protobuf:"bytes,1,opt,name=firmware_url,json=firmwareUrl,proto3" json:"firmware_url,omitempty"`
+	TargetVersion         string                 `protobuf:"bytes,2,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	StationIds            []int64                `protobuf:"varint,3,rep,packed,name=station_ids,json=stationIds,proto3" json:"station_ids,omitempty"`
+	WaveSize              int64                  `protobuf:"varint,4,opt,name=wave_size,json=waveSize,proto3" json:"wave_size,omitempty"`
+	FailureThreshold      int64                  `protobuf:"varint,5,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	StationTimeoutSeconds int64                  `protobuf:"varint,6,opt,name=station_timeout_seconds,json=stationTimeoutSeconds,proto3" json:"station_timeout_seconds,omitempty"`
+	RetrieveDate          string                 `protobuf:"bytes,7,opt,name=retrieve_date,json=retrieveDate,proto3" json:"retrieve_date,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateFirmwareCampaignRequest) Reset() {
+	*x = CreateFirmwareCampaignRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFirmwareCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFirmwareCampaignRequest) ProtoMessage() {}
+
+func (x *CreateFirmwareCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFirmwareCampaignRequest.ProtoReflect.Descriptor instead.
+func (*CreateFirmwareCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateFirmwareCampaignRequest) GetFirmwareUrl() string {
+	if x != nil {
+		return x.FirmwareUrl
+	}
+	return ""
+}
+
+func (x *CreateFirmwareCampaignRequest) GetTargetVersion() string {
+	if x != nil {
+		return x.TargetVersion
+	}
+	return ""
+}
+
+func (x *CreateFirmwareCampaignRequest) GetStationIds() []int64 {
+	if x != nil {
+		return x.StationIds
+	}
+	return nil
+}
+
+func (x *CreateFirmwareCampaignRequest) GetWaveSize() int64 {
+	if x != nil {
+		return x.WaveSize
+	}
+	return 0
+}
+
+func (x *CreateFirmwareCampaignRequest) GetFailureThreshold() int64 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+func (x *CreateFirmwareCampaignRequest) GetStationTimeoutSeconds() int64 {
+	if x != nil {
+		return x.StationTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *CreateFirmwareCampaignRequest) GetRetrieveDate() string {
+	if x != nil {
+		return x.RetrieveDate
+	}
+	return ""
+}
+
+type FirmwareCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int64                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirmwareCampaignRequest) Reset() {
+	*x = FirmwareCampaignRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirmwareCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirmwareCampaignRequest) ProtoMessage() {}
+
+func (x *FirmwareCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirmwareCampaignRequest.ProtoReflect.Descriptor instead.
+func (*FirmwareCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FirmwareCampaignRequest) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+// ResumeFirmwareCampaignRequest снимает кампанию с паузы. Порог ошибок после возобновления отсчитывается
+// заново: уже завершившиеся ошибкой станции не учитываются. failure_threshold > 0 заменяет порог ошибок
+type ResumeFirmwareCampaignRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId       int64                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	FailureThreshold int64                  `protobuf:"varint,2,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ResumeFirmwareCampaignRequest) Reset() {
+	*x = ResumeFirmwareCampaignRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeFirmwareCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeFirmwareCampaignRequest) ProtoMessage() {}
+
+func (x *ResumeFirmwareCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeFirmwareCampaignRequest.ProtoReflect.Descriptor instead.
+func (*ResumeFirmwareCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ResumeFirmwareCampaignRequest) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *ResumeFirmwareCampaignRequest) GetFailureThreshold() int64 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+type FirmwareCampaign struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirmwareUrl           string                 `protobuf:"bytes,2,opt,name=firmware_url,json=firmwareUrl,proto3" json:"firmware_url,omitempty"`
+	TargetVersion         string                 `protobuf:"bytes,3,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	RetrieveDate          string                 `protobuf:"bytes,4,opt,name=retrieve_date,json=retrieveDate,proto3" json:"retrieve_date,omitempty"`
+	WaveSize              int64                  `protobuf:"varint,5,opt,name=wave_size,json=waveSize,proto3" json:"wave_size,omitempty"`
+	CurrentWave           int64                  `protobuf:"varint,6,opt,name=current_wave,json=currentWave,proto3" json:"current_wave,omitempty"`
+	FailureThreshold      int64                  `protobuf:"varint,7,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	StationTimeoutSeconds int64                  `protobuf:"varint,8,opt,name=station_timeout_seconds,json=stationTimeoutSeconds,proto3" json:"station_timeout_seconds,omitempty"`
+	// status - running, paused или completed
+	Status    string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt string `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt string `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// resumed_failures - ошибки, учтённые при последнем возобновлении, порог применяется к ошибкам сверх них
+	ResumedFailures int64 `protobuf:"varint,12,opt,name=resumed_failures,json=resumedFailures,proto3" json:"resumed_failures,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FirmwareCampaign) Reset() {
+	*x = FirmwareCampaign{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirmwareCampaign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirmwareCampaign) ProtoMessage() {}
+
+func (x *FirmwareCampaign) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirmwareCampaign.ProtoReflect.Descriptor instead.
+func (*FirmwareCampaign) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FirmwareCampaign) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FirmwareCampaign) GetFirmwareUrl() string {
+	if x != nil {
+		return x.FirmwareUrl
+	}
+	return ""
+}
+
+func (x *FirmwareCampaign) GetTargetVersion() string {
+	if x != nil {
+		return x.TargetVersion
+	}
+	return ""
+}
+
+func (x *FirmwareCampaign) GetRetrieveDate() string {
+	if x != nil {
+		return x.RetrieveDate
+	}
+	return ""
+}
+
+func (x *FirmwareCampaign) GetWaveSize() int64 {
+	if x != nil {
+		return x.WaveSize
+	}
+	return 0
+}
+
+func (x *FirmwareCampaign) GetCurrentWave() int64 {
+	if x != nil {
+		return x.CurrentWave
+	}
+	return 0
+}
+
+func (x *FirmwareCampaign) GetFailureThreshold() int64 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+func (x *FirmwareCampaign) GetStationTimeoutSeconds() int64 {
+	if x != nil {
+		return x.StationTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *FirmwareCampaign) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FirmwareCampaign) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *FirmwareCampaign) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *FirmwareCampaign) GetResumedFailures() int64 {
+	if x != nil {
+		return x.ResumedFailures
+	}
+	return 0
+}
+
+type FirmwareCampaignStation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StationId int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Wave      int64                  `protobuf:"varint,2,opt,name=wave,proto3" json:"wave,omitempty"`
+	// status - pending, sent, Downloading, Downloaded, Installing, Installed, verified или failed
+	Status        string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirmwareCampaignStation) Reset() {
+	*x = FirmwareCampaignStation{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirmwareCampaignStation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirmwareCampaignStation) ProtoMessage() {}
+
+func (x *FirmwareCampaignStation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirmwareCampaignStation.ProtoReflect.Descriptor instead.
+func (*FirmwareCampaignStation) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FirmwareCampaignStation) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *FirmwareCampaignStation) GetWave() int64 {
+	if x != nil {
+		return x.Wave
+	}
+	return 0
+}
+
+func (x *FirmwareCampaignStation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FirmwareCampaignStation) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FirmwareCampaignStation) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type FirmwareCampaignResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Campaign      *FirmwareCampaign          `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	Stations      []*FirmwareCampaignStation `protobuf:"bytes,2,rep,name=stations,proto3" json:"stations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirmwareCampaignResponse) Reset() {
+	*x = FirmwareCampaignResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirmwareCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirmwareCampaignResponse) ProtoMessage() {}
+
+func (x *FirmwareCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirmwareCampaignResponse.ProtoReflect.Descriptor instead.
+func (*FirmwareCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FirmwareCampaignResponse) GetCampaign() *FirmwareCampaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *FirmwareCampaignResponse) GetStations() []*FirmwareCampaignStation {
+	if x != nil {
+		return x.Stations
+	}
+	return nil
+}
+
+// GetDiagnosticsRequest просит станцию выгрузить диагностику. Пустой location - встроенный сервер,
+// станции выдаётся подписанный адрес выгрузки. start_time и stop_time в RFC3339
+type GetDiagnosticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	StartTime     string                 `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	StopTime      string                 `protobuf:"bytes,4,opt,name=stop_time,json=stopTime,proto3" json:"stop_time,omitempty"`
+	Retries       int64                  `protobuf:"varint,5,opt,name=retries,proto3" json:"retries,omitempty"`
+	RetryInterval int64                  `protobuf:"varint,6,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiagnosticsRequest) Reset() {
+	*x = GetDiagnosticsRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiagnosticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiagnosticsRequest) ProtoMessage() {}
+
+func (x *GetDiagnosticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiagnosticsRequest.ProtoReflect.Descriptor instead.
+func (*GetDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetDiagnosticsRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *GetDiagnosticsRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *GetDiagnosticsRequest) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *GetDiagnosticsRequest) GetStopTime() string {
+	if x != nil {
+		return x.StopTime
+	}
+	return ""
+}
+
+func (x *GetDiagnosticsRequest) GetRetries() int64 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *GetDiagnosticsRequest) GetRetryInterval() int64 {
+	if x != nil {
+		return x.RetryInterval
+	}
+	return 0
+}
+
+type DiagnosticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StationId     int64                  `protobuf:"varint,2,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	StartTime     string                 `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	StopTime      string                 `protobuf:"bytes,5,opt,name=stop_time,json=stopTime,proto3" json:"stop_time,omitempty"`
+	Retries       int64                  `protobuf:"varint,6,opt,name=retries,proto3" json:"retries,omitempty"`
+	RetryInterval int64                  `protobuf:"varint,7,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
+	// status - requested, no_file (у станции нет диагностики), failed (команда не отправлена или отклонена),
+	// uploading, uploaded или upload_failed
+	Status        string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	FileName      string `protobuf:"bytes,9,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	CreatedAt     string `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticsRequest) Reset() {
+	*x = DiagnosticsRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticsRequest) ProtoMessage() {}
+
+func (x *DiagnosticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticsRequest.ProtoReflect.Descriptor instead.
+func (*DiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DiagnosticsRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DiagnosticsRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *DiagnosticsRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *DiagnosticsRequest) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *DiagnosticsRequest) GetStopTime() string {
+	if x != nil {
+		return x.StopTime
+	}
+	return ""
+}
+
+func (x *DiagnosticsRequest) GetRetries() int64 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *DiagnosticsRequest) GetRetryInterval() int64 {
+	if x != nil {
+		return x.RetryInterval
+	}
+	return 0
+}
+
+func (x *DiagnosticsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DiagnosticsRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DiagnosticsRequest) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *DiagnosticsRequest) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type GetDiagnosticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *DiagnosticsRequest    `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiagnosticsResponse) Reset() {
+	*x = GetDiagnosticsResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiagnosticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiagnosticsResponse) ProtoMessage() {}
+
+func (x *GetDiagnosticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiagnosticsResponse.ProtoReflect.Descriptor instead.
+func (*GetDiagnosticsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDiagnosticsResponse) GetRequest() *DiagnosticsRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type ListDiagnosticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDiagnosticsRequest) Reset() {
+	*x = ListDiagnosticsRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDiagnosticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDiagnosticsRequest) ProtoMessage() {}
+
+func (x *ListDiagnosticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDiagnosticsRequest.ProtoReflect.Descriptor instead.
+func (*ListDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListDiagnosticsRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+type DiagnosticsFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestId     int64                  `protobuf:"varint,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticsFile) Reset() {
+	*x = DiagnosticsFile{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticsFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticsFile) ProtoMessage() {}
+
+func (x *DiagnosticsFile) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticsFile.ProtoReflect.Descriptor instead.
+func (*DiagnosticsFile) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DiagnosticsFile) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DiagnosticsFile) GetRequestId() int64 {
+	if x != nil {
+		return x.RequestId
+	}
+	return 0
+}
+
+func (x *DiagnosticsFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiagnosticsFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DiagnosticsFile) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListDiagnosticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*DiagnosticsRequest  `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	Files         []*DiagnosticsFile     `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDiagnosticsResponse) Reset() {
+	*x = ListDiagnosticsResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDiagnosticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDiagnosticsResponse) ProtoMessage() {}
+
+func (x *ListDiagnosticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDiagnosticsResponse.ProtoReflect.Descriptor instead.
+func (*ListDiagnosticsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListDiagnosticsResponse) GetRequests() []*DiagnosticsRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *ListDiagnosticsResponse) GetFiles() []*DiagnosticsFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type DownloadDiagnosticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadDiagnosticsRequest) Reset() {
+	*x = DownloadDiagnosticsRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadDiagnosticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadDiagnosticsRequest) ProtoMessage() {}
+
+func (x *DownloadDiagnosticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadDiagnosticsRequest.ProtoReflect.Descriptor instead.
+func (*DownloadDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DownloadDiagnosticsRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *DownloadDiagnosticsRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+// DiagnosticsFileChunk - часть файла диагностики, name передаётся в первой части
+type DiagnosticsFileChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticsFileChunk) Reset() {
+	*x = DiagnosticsFileChunk{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticsFileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticsFileChunk) ProtoMessage() {}
+
+func (x *DiagnosticsFileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticsFileChunk.ProtoReflect.Descriptor instead.
+func (*DiagnosticsFileChunk) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DiagnosticsFileChunk) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiagnosticsFileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ReserveNowRequest резервирует коннектор (ocpp_id, 0 - любой коннектор станции) за id_tag до expiry_date (RFC3339)
+type ReserveNowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	ConnectorId   int64                  `protobuf:"varint,2,opt,name=connector_id,json=connectorId,proto3" json:"connector_id,omitempty"`
+	IdTag         string                 `protobuf:"bytes,3,opt,name=id_tag,json=idTag,proto3" json:"id_tag,omitempty"`
+	ParentIdTag   string                 `protobuf:"bytes,4,opt,name=parent_id_tag,json=parentIdTag,proto3" json:"parent_id_tag,omitempty"`
+	ExpiryDate    string                 `protobuf:"bytes,5,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveNowRequest) Reset() {
+	*x = ReserveNowRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveNowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveNowRequest) ProtoMessage() {}
+
+func (x *ReserveNowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveNowRequest.ProtoReflect.Descriptor instead.
+func (*ReserveNowRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReserveNowRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *ReserveNowRequest) GetConnectorId() int64 {
+	if x != nil {
+		return x.ConnectorId
+	}
+	return 0
+}
+
+func (x *ReserveNowRequest) GetIdTag() string {
+	if x != nil {
+		return x.IdTag
+	}
+	return ""
+}
+
+func (x *ReserveNowRequest) GetParentIdTag() string {
+	if x != nil {
+		return x.ParentIdTag
+	}
+	return ""
+}
+
+func (x *ReserveNowRequest) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+type Reservation struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StationId   int64                  `protobuf:"varint,2,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	ConnectorId int64                  `protobuf:"varint,3,opt,name=connector_id,json=connectorId,proto3" json:"connector_id,omitempty"`
+	IdTag       string                 `protobuf:"bytes,4,opt,name=id_tag,json=idTag,proto3" json:"id_tag,omitempty"`
+	ParentIdTag string                 `protobuf:"bytes,5,opt,name=parent_id_tag,json=parentIdTag,proto3" json:"parent_id_tag,omitempty"`
+	ExpiryDate  string                 `protobuf:"bytes,6,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	// status - active, used, cancelled, expired или rejected
+	Status        string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	SessionId     int64  `protobuf:"varint,8,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	CreatedAt     string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Reservation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Reservation) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *Reservation) GetConnectorId() int64 {
+	if x != nil {
+		return x.ConnectorId
+	}
+	return 0
+}
+
+func (x *Reservation) GetIdTag() string {
+	if x != nil {
+		return x.IdTag
+	}
+	return ""
+}
+
+func (x *Reservation) GetParentIdTag() string {
+	if x != nil {
+		return x.ParentIdTag
+	}
+	return ""
+}
+
+func (x *Reservation) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+func (x *Reservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reservation) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *Reservation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Reservation) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ReserveNowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveNowResponse) Reset() {
+	*x = ReserveNowResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveNowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveNowResponse) ProtoMessage() {}
+
+func (x *ReserveNowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveNowResponse.ProtoReflect.Descriptor instead.
+func (*ReserveNowResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReserveNowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReserveNowResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type CancelReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	ReservationId int64                  `protobuf:"varint,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReservationRequest) Reset() {
+	*x = CancelReservationRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationRequest) ProtoMessage() {}
+
+func (x *CancelReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationRequest.ProtoReflect.Descriptor instead.
+func (*CancelReservationRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CancelReservationRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *CancelReservationRequest) GetReservationId() int64 {
+	if x != nil {
+		return x.ReservationId
+	}
+	return 0
+}
+
+type CancelReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReservationResponse) Reset() {
+	*x = CancelReservationResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationResponse) ProtoMessage() {}
+
+func (x *CancelReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationResponse.ProtoReflect.Descriptor instead.
+func (*CancelReservationResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CancelReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type ListReservationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReservationsRequest) Reset() {
+	*x = ListReservationsRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReservationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReservationsRequest) ProtoMessage() {}
+
+func (x *ListReservationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReservationsRequest.ProtoReflect.Descriptor instead.
+func (*ListReservationsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListReservationsRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+type ListReservationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservations  []*Reservation         `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReservationsResponse) Reset() {
+	*x = ListReservationsResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReservationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReservationsResponse) ProtoMessage() {}
+
+func (x *ListReservationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReservationsResponse.ProtoReflect.Descriptor instead.
+func (*ListReservationsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListReservationsResponse) GetReservations() []*Reservation {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+// SetIdTagRequest создаёт или изменяет idTag пользователя. status - Accepted, Blocked, Expired или Invalid,
+// expiry_date в RFC3339, пусто - бессрочно. Изменение рассылается подключённым станциям в локальный список.
+// allowed_station_ids и allowed_location_ids ограничивают станции, где idTag принимается, пусто - везде
+type SetIdTagRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	IdTag              string                 `protobuf:"bytes,1,opt,name=id_tag,json=idTag,proto3" json:"id_tag,omitempty"`
+	UserId             int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ParentIdTag        string                 `protobuf:"bytes,3,opt,name=parent_id_tag,json=parentIdTag,proto3" json:"parent_id_tag,omitempty"`
+	Status             string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiryDate         string                 `protobuf:"bytes,5,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	AllowedStationIds  []int64                `protobuf:"varint,6,rep,packed,name=allowed_station_ids,json=allowedStationIds,proto3" json:"allowed_station_ids,omitempty"`
+	AllowedLocationIds []int64                `protobuf:"varint,7,rep,packed,name=allowed_location_ids,json=allowedLocationIds,proto3" json:"allowed_location_ids,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SetIdTagRequest) Reset() {
+	*x = SetIdTagRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIdTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIdTagRequest) ProtoMessage() {}
+
+func (x *SetIdTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIdTagRequest.ProtoReflect.Descriptor instead.
+func (*SetIdTagRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SetIdTagRequest) GetIdTag() string {
+	if x != nil {
+		return x.IdTag
+	}
+	return ""
+}
+
+func (x *SetIdTagRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetIdTagRequest) GetParentIdTag() string {
+	if x != nil {
+		return x.ParentIdTag
+	}
+	return ""
+}
+
+func (x *SetIdTagRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SetIdTagRequest) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+func (x *SetIdTagRequest) GetAllowedStationIds() []int64 {
+	if x != nil {
+		return x.AllowedStationIds
+	}
+	return nil
+}
+
+func (x *SetIdTagRequest) GetAllowedLocationIds() []int64 {
+	if x != nil {
+		return x.AllowedLocationIds
+	}
+	return nil
+}
+
+type IdTag struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	IdTag       string                 `protobuf:"bytes,1,opt,name=id_tag,json=idTag,proto3" json:"id_tag,omitempty"`
+	UserId      int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ParentIdTag string                 `protobuf:"bytes,3,opt,name=parent_id_tag,json=parentIdTag,proto3" json:"parent_id_tag,omitempty"`
+	Status      string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiryDate  string                 `protobuf:"bytes,5,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	// version - версия локального списка, в которой idTag изменился последний раз
+	Version            int64   `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt          string  `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AllowedStationIds  []int64 `protobuf:"varint,8,rep,packed,name=allowed_station_ids,json=allowedStationIds,proto3" json:"allowed_station_ids,omitempty"`
+	AllowedLocationIds []int64 `protobuf:"varint,9,rep,packed,name=allowed_location_ids,json=allowedLocationIds,proto3" json:"allowed_location_ids,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *IdTag) Reset() {
+	*x = IdTag{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdTag) ProtoMessage() {}
+
+func (x *IdTag) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdTag.ProtoReflect.Descriptor instead.
+func (*IdTag) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *IdTag) GetIdTag() string {
+	if x != nil {
+		return x.IdTag
+	}
+	return ""
+}
+
+func (x *IdTag) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *IdTag) GetParentIdTag() string {
+	if x != nil {
+		return x.ParentIdTag
+	}
+	return ""
+}
+
+func (x *IdTag) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *IdTag) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+func (x *IdTag) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *IdTag) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *IdTag) GetAllowedStationIds() []int64 {
+	if x != nil {
+		return x.AllowedStationIds
+	}
+	return nil
+}
+
+func (x *IdTag) GetAllowedLocationIds() []int64 {
+	if x != nil {
+		return x.AllowedLocationIds
+	}
+	return nil
+}
+
+type SetIdTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IdTag         *IdTag                 `protobuf:"bytes,1,opt,name=id_tag,json=idTag,proto3" json:"id_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIdTagResponse) Reset() {
+	*x = SetIdTagResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIdTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIdTagResponse) ProtoMessage() {}
+
+func (x *SetIdTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIdTagResponse.ProtoReflect.Descriptor instead.
+func (*SetIdTagResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetIdTagResponse) GetIdTag() *IdTag {
+	if x != nil {
+		return x.IdTag
+	}
+	return nil
+}
+
+// SyncLocalListRequest приводит локальный список станции к текущей версии.
+// full = true отправляет список целиком, иначе только изменения, если версия станции известна
+type SyncLocalListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncLocalListRequest) Reset() {
+	*x = SyncLocalListRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncLocalListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncLocalListRequest) ProtoMessage() {}
+
+func (x *SyncLocalListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncLocalListRequest.ProtoReflect.Descriptor instead.
+func (*SyncLocalListRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SyncLocalListRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *SyncLocalListRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+type SyncLocalListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncLocalListResponse) Reset() {
+	*x = SyncLocalListResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncLocalListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncLocalListResponse) ProtoMessage() {}
+
+func (x *SyncLocalListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncLocalListResponse.ProtoReflect.Descriptor instead.
+func (*SyncLocalListResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SyncLocalListResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SyncLocalListResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetLocalListVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLocalListVersionRequest) Reset() {
+	*x = GetLocalListVersionRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLocalListVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLocalListVersionRequest) ProtoMessage() {}
+
+func (x *GetLocalListVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLocalListVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetLocalListVersionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetLocalListVersionRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+type GetLocalListVersionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// station_version - версия, которую сообщила станция, synced_version - последняя отправленная ей,
+	// list_version - текущая версия списка
+	StationVersion int64 `protobuf:"varint,1,opt,name=station_version,json=stationVersion,proto3" json:"station_version,omitempty"`
+	SyncedVersion  int64 `protobuf:"varint,2,opt,name=synced_version,json=syncedVersion,proto3" json:"synced_version,omitempty"`
+	ListVersion    int64 `protobuf:"varint,3,opt,name=list_version,json=listVersion,proto3" json:"list_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetLocalListVersionResponse) Reset() {
+	*x = GetLocalListVersionResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLocalListVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLocalListVersionResponse) ProtoMessage() {}
+
+func (x *GetLocalListVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLocalListVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetLocalListVersionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetLocalListVersionResponse) GetStationVersion() int64 {
+	if x != nil {
+		return x.StationVersion
+	}
+	return 0
+}
+
+func (x *GetLocalListVersionResponse) GetSyncedVersion() int64 {
+	if x != nil {
+		return x.SyncedVersion
+	}
+	return 0
+}
+
+func (x *GetLocalListVersionResponse) GetListVersion() int64 {
+	if x != nil {
+		return x.ListVersion
+	}
+	return 0
+}
+
+// ClearCacheRequest очищает кэш авторизации станции. При блокировке idTag через SetIdTag
+// кэш очищается на всех подключённых станциях автоматически
+type ClearCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearCacheRequest) Reset() {
+	*x = ClearCacheRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCacheRequest) ProtoMessage() {}
+
+func (x *ClearCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCacheRequest.ProtoReflect.Descriptor instead.
+func (*ClearCacheRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ClearCacheRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+type ClearCacheResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// status - Accepted. Rejected возвращается ошибкой
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearCacheResponse) Reset() {
+	*x = ClearCacheResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCacheResponse) ProtoMessage() {}
+
+func (x *ClearCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCacheResponse.ProtoReflect.Descriptor instead.
+func (*ClearCacheResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ClearCacheResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ClearCacheResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// SetAuthorizationCacheRequest включает или выключает кэш авторизации станции (AuthorizationCacheEnabled).
+// Только для станций OCPP 1.6J
+type SetAuthorizationCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StationId     int64                  `protobuf:"varint,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAuthorizationCacheRequest) Reset() {
+	*x = SetAuthorizationCacheRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAuthorizationCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAuthorizationCacheRequest) ProtoMessage() {}
+
+func (x *SetAuthorizationCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAuthorizationCacheRequest.ProtoReflect.Descriptor instead.
+func (*SetAuthorizationCacheRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SetAuthorizationCacheRequest) GetStationId() int64 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *SetAuthorizationCacheRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetAuthorizationCacheResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// status - Accepted или RebootRequired
+	Status         string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	RebootRequired bool   `protobuf:"varint,3,opt,name=reboot_required,json=rebootRequired,proto3" json:"reboot_required,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetAuthorizationCacheResponse) Reset() {
+	*x = SetAuthorizationCacheResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAuthorizationCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAuthorizationCacheResponse) ProtoMessage() {}
+
+func (x *SetAuthorizationCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAuthorizationCacheResponse.ProtoReflect.Descriptor instead.
+func (*SetAuthorizationCacheResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SetAuthorizationCacheResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetAuthorizationCacheResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SetAuthorizationCacheResponse) GetRebootRequired() bool {
+	if x != nil {
+		return x.RebootRequired
+	}
+	return false
+}
+
+// SetIdTagGroupRequest задаёт ограничения группы карт с общим parent_id_tag, 0 - без ограничения.
+// monthly_energy_kwh - энергия всех карт группы за календарный месяц. allowed_station_ids и allowed_location_ids -
+// ограничения самого parent_id_tag (те же, что в SetIdTag), действуют на все карты группы.
+// Локальные списки станций получат новые ограничения при полной синхронизации
+type SetIdTagGroupRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ParentIdTag           string                 `protobuf:"bytes,1,opt,name=parent_id_tag,json=parentIdTag,proto3" json:"parent_id_tag,omitempty"`
+	Name                  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MaxConcurrentSessions int64                  `protobuf:"varint,3,opt,name=max_concurrent_sessions,json=maxConcurrentSessions,proto3" json:"max_concurrent_sessions,omitempty"`
+	MonthlyEnergyKwh      float64                `protobuf:"fixed64,4,opt,name=monthly_energy_kwh,json=monthlyEnergyKwh,proto3" json:"monthly_energy_kwh,omitempty"`
+	AllowedStationIds     []int64                `protobuf:"varint,5,rep,packed,name=allowed_station_ids,json=allowedStationIds,proto3" json:"allowed_station_ids,omitempty"`
+	AllowedLocationIds    []int64                `protobuf:"varint,6,rep,packed,name=allowed_location_ids,json=allowedLocationIds,proto3" json:"allowed_location_ids,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SetIdTagGroupRequest) Reset() {
+	*x = SetIdTagGroupRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIdTagGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIdTagGroupRequest) ProtoMessage() {}
+
+func (x *SetIdTagGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIdTagGroupRequest.ProtoReflect.Descriptor instead.
+func (*SetIdTagGroupRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SetIdTagGroupRequest) GetParentIdTag() string {
+	if x != nil {
+		return x.ParentIdTag
+	}
+	return ""
+}
+
+func (x *SetIdTagGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetIdTagGroupRequest) GetMaxConcurrentSessions() int64 {
+	if x != nil {
+		return x.MaxConcurrentSessions
+	}
+	return 0
+}
+
+func (x *SetIdTagGroupRequest) GetMonthlyEnergyKwh() float64 {
+	if x != nil {
+		return x.MonthlyEnergyKwh
+	}
+	return 0
+}
+
+func (x *SetIdTagGroupRequest) GetAllowedStationIds() []int64 {
+	if x != nil {
+		return x.AllowedStationIds
+	}
+	return nil
+}
+
+func (x *SetIdTagGroupRequest) GetAllowedLocationIds() []int64 {
+	if x != nil {
+		return x.AllowedLocationIds
+	}
+	return nil
+}
+
+type GetIdTagGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentIdTag   string                 `protobuf:"bytes,1,opt,name=parent_id_tag,json=parentIdTag,proto3" json:"parent_id_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIdTagGroupRequest) Reset() {
+	*x = GetIdTagGroupRequest{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdTagGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdTagGroupRequest) ProtoMessage() {}
+
+func (x *GetIdTagGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdTagGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetIdTagGroupRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetIdTagGroupRequest) GetParentIdTag() string {
+	if x != nil {
+		return x.ParentIdTag
+	}
+	return ""
+}
+
+type IdTagGroup struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ParentIdTag           string                 `protobuf:"bytes,1,opt,name=parent_id_tag,json=parentIdTag,proto3" json:"parent_id_tag,omitempty"`
+	Name                  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MaxConcurrentSessions int64                  `protobuf:"varint,3,opt,name=max_concurrent_sessions,json=maxConcurrentSessions,proto3" json:"max_concurrent_sessions,omitempty"`
+	MonthlyEnergyKwh      float64                `protobuf:"fixed64,4,opt,name=monthly_energy_kwh,json=monthlyEnergyKwh,proto3" json:"monthly_energy_kwh,omitempty"`
+	AllowedStationIds     []int64                `protobuf:"varint,5,rep,packed,name=allowed_station_ids,json=allowedStationIds,proto3" json:"allowed_station_ids,omitempty"`
+	AllowedLocationIds    []int64                `protobuf:"varint,6,rep,packed,name=allowed_location_ids,json=allowedLocationIds,proto3" json:"allowed_location_ids,omitempty"`
+	UpdatedAt             string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *IdTagGroup) Reset() {
+	*x = IdTagGroup{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdTagGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdTagGroup) ProtoMessage() {}
+
+func (x *IdTagGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdTagGroup.ProtoReflect.Descriptor instead.
+func (*IdTagGroup) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *IdTagGroup) GetParentIdTag() string {
+	if x != nil {
+		return x.ParentIdTag
+	}
+	return ""
+}
+
+func (x *IdTagGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IdTagGroup) GetMaxConcurrentSessions() int64 {
+	if x != nil {
+		return x.MaxConcurrentSessions
+	}
+	return 0
+}
+
+func (x *IdTagGroup) GetMonthlyEnergyKwh() float64 {
+	if x != nil {
+		return x.MonthlyEnergyKwh
+	}
+	return 0
+}
+
+func (x *IdTagGroup) GetAllowedStationIds() []int64 {
+	if x != nil {
+		return x.AllowedStationIds
+	}
+	return nil
+}
+
+func (x *IdTagGroup) GetAllowedLocationIds() []int64 {
+	if x != nil {
+		return x.AllowedLocationIds
+	}
+	return nil
+}
+
+func (x *IdTagGroup) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// IdTagGroupResponse - группа и её текущее использование: идущие транзакции и энергия за месяц
+type IdTagGroupResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Group          *IdTagGroup            `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	ActiveSessions int64                  `protobuf:"varint,2,opt,name=active_sessions,json=activeSessions,proto3" json:"active_sessions,omitempty"`
+	MonthEnergyKwh float64                `protobuf:"fixed64,3,opt,name=month_energy_kwh,json=monthEnergyKwh,proto3" json:"month_energy_kwh,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *IdTagGroupResponse) Reset() {
+	*x = IdTagGroupResponse{}
+	mi := &file_internal_proto_control_control_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdTagGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdTagGroupResponse) ProtoMessage() {}
+
+func (x *IdTagGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_control_control_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdTagGroupResponse.ProtoReflect.Descriptor instead.
+func (*IdTagGroupResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_control_control_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *IdTagGroupResponse) GetGroup() *IdTagGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *IdTagGroupResponse) GetActiveSessions() int64 {
+	if x != nil {
+		return x.ActiveSessions
+	}
+	return 0
+}
+
+func (x *IdTagGroupResponse) GetMonthEnergyKwh() float64 {
+	if x != nil {
+		return x.MonthEnergyKwh
+	}
+	return 0
+}
+
+var File_internal_proto_control_control_proto protoreflect.FileDescriptor
+
+const file_internal_proto_control_control_proto_rawDesc = "" +
+	"\n" +
+	"$internal/proto/control/control.proto\x12\acommand\"=\n" +
+	"\x11CustomErrorDetail\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x03R\x04code\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x82\x01\n" +
+	"\x0fOcppErrorDetail\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x01 \x01(\tR\terrorCode\x12+\n" +
+	"\x11error_description\x18\x02 \x01(\tR\x10errorDescription\x12#\n" +
+	"\rerror_details\x18\x03 \x01(\tR\ferrorDetails\"E\n" +
+	"\x0fCommandResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"v\n" +
+	"\x13StartStationRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12!\n" +
+	"\fconnector_id\x18\x02 \x01(\x03R\vconnectorId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\x03R\tsessionId\"0\n" +
+	"\x14StartStationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"R\n" +
+	"\x12StopStationRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\x03R\tsessionId\"/\n" +
+	"\x13StopStationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb2\x01\n" +
+	"\x13ResetStationRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12&\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x12.command.ResetTypeR\x04type\x12\"\n" +
+	"\rwait_for_boot\x18\x03 \x01(\bR\vwaitForBoot\x120\n" +
+	"\x14boot_timeout_seconds\x18\x04 \x01(\x03R\x12bootTimeoutSeconds\"`\n" +
+	"\x14ResetStationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06booted\x18\x03 \x01(\bR\x06booted\"\x8c\x01\n" +
+	"\x19ChangeAvailabilityRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12!\n" +
+	"\fconnector_id\x18\x02 \x01(\x03R\vconnectorId\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.command.AvailabilityTypeR\x04type\"N\n" +
+	"\x1aChangeAvailabilityResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x9e\x01\n" +
+	"\x10ConfigurationKey\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x1a\n" +
+	"\breadonly\x18\x03 \x01(\bR\breadonly\x12'\n" +
+	"\x0freboot_required\x18\x04 \x01(\bR\x0erebootRequired\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"d\n" +
+	"\x17GetConfigurationRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\x12\x16\n" +
+	"\x06cached\x18\x03 \x01(\bR\x06cached\"\x87\x01\n" +
+	"\x18GetConfigurationResponse\x12H\n" +
+	"\x12configuration_keys\x18\x01 \x03(\v2\x19.command.ConfigurationKeyR\x11configurationKeys\x12!\n" +
+	"\funknown_keys\x18\x02 \x03(\tR\vunknownKeys\"c\n" +
+	"\x1aChangeConfigurationRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"x\n" +
+	"\x1bChangeConfigurationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12'\n" +
+	"\x0freboot_required\x18\x03 \x01(\bR\x0erebootRequired\"=\n" +
+	"\x1cGetConfigurationDriftRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\"p\n" +
+	"\x12ConfigurationDrift\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\bexpected\x18\x02 \x01(\tR\bexpected\x12\x16\n" +
+	"\x06actual\x18\x03 \x01(\tR\x06actual\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\"\x80\x01\n" +
+	"\x1dGetConfigurationDriftResponse\x12\x16\n" +
+	"\x06vendor\x18\x01 \x01(\tR\x06vendor\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x121\n" +
+	"\x05drift\x18\x03 \x03(\v2\x1b.command.ConfigurationDriftR\x05drift\"\x85\x01\n" +
+	"\x16UnlockConnectorRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12!\n" +
+	"\fconnector_id\x18\x02 \x01(\x03R\vconnectorId\x12)\n" +
+	"\x10stop_transaction\x18\x03 \x01(\bR\x0fstopTransaction\"K\n" +
+	"\x17UnlockConnectorResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\xa3\x01\n" +
+	"\x15TriggerMessageRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12H\n" +
+	"\x11requested_message\x18\x02 \x01(\x0e2\x1b.command.TriggerMessageTypeR\x10requestedMessage\x12!\n" +
+	"\fconnector_id\x18\x03 \x01(\x03R\vconnectorId\"J\n" +
+	"\x16TriggerMessageResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\xb8\x01\n" +
+	"\x15UpdateFirmwareRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12#\n" +
+	"\rretrieve_date\x18\x03 \x01(\tR\fretrieveDate\x12\x18\n" +
+	"\aretries\x18\x04 \x01(\x03R\aretries\x12%\n" +
+	"\x0eretry_interval\x18\x05 \x01(\x03R\rretryInterval\"2\n" +
+	"\x16UpdateFirmwareResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb1\x02\n" +
+	"\x1dCreateFirmwareCampaignRequest\x12!\n" +
+	"\ffirmware_url\x18\x01 \x01(\tR\vfirmwareUrl\x12%\n" +
+	"\x0etarget_version\x18\x02 \x01(\tR\rtargetVersion\x12\x1f\n" +
+	"\vstation_ids\x18\x03 \x03(\x03R\n" +
+	"stationIds\x12\x1b\n" +
+	"\twave_size\x18\x04 \x01(\x03R\bwaveSize\x12+\n" +
+	"\x11failure_threshold\x18\x05 \x01(\x03R\x10failureThreshold\x126\n" +
+	"\x17station_timeout_seconds\x18\x06 \x01(\x03R\x15stationTimeoutSeconds\x12#\n" +
+	"\rretrieve_date\x18\a \x01(\tR\fretrieveDate\":\n" +
+	"\x17FirmwareCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x03R\n" +
+	"campaignId\"m\n" +
+	"\x1dResumeFirmwareCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x03R\n" +
+	"campaignId\x12+\n" +
+	"\x11failure_threshold\x18\x02 \x01(\x03R\x10failureThreshold\"\xb7\x03\n" +
+	"\x10FirmwareCampaign\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\ffirmware_url\x18\x02 \x01(\tR\vfirmwareUrl\x12%\n" +
+	"\x0etarget_version\x18\x03 \x01(\tR\rtargetVersion\x12#\n" +
+	"\rretrieve_date\x18\x04 \x01(\tR\fretrieveDate\x12\x1b\n" +
+	"\twave_size\x18\x05 \x01(\x03R\bwaveSize\x12!\n" +
+	"\fcurrent_wave\x18\x06 \x01(\x03R\vcurrentWave\x12+\n" +
+	"\x11failure_threshold\x18\a \x01(\x03R\x10failureThreshold\x126\n" +
+	"\x17station_timeout_seconds\x18\b \x01(\x03R\x15stationTimeoutSeconds\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\tR\tupdatedAt\x12)\n" +
+	"\x10resumed_failures\x18\f \x01(\x03R\x0fresumedFailures\"\x99\x01\n" +
+	"\x17FirmwareCampaignStation\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x12\n" +
+	"\x04wave\x18\x02 \x01(\x03R\x04wave\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"\x8f\x01\n" +
+	"\x18FirmwareCampaignResponse\x125\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x19.command.FirmwareCampaignR\bcampaign\x12<\n" +
+	"\bstations\x18\x02 \x03(\v2 .command.FirmwareCampaignStationR\bstations\"\xcf\x01\n" +
+	"\x15GetDiagnosticsRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\tR\tstartTime\x12\x1b\n" +
+	"\tstop_time\x18\x04 \x01(\tR\bstopTime\x12\x18\n" +
+	"\aretries\x18\x05 \x01(\x03R\aretries\x12%\n" +
+	"\x0eretry_interval\x18\x06 \x01(\x03R\rretryInterval\"\xcf\x02\n" +
+	"\x12DiagnosticsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x02 \x01(\x03R\tstationId\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\tR\tstartTime\x12\x1b\n" +
+	"\tstop_time\x18\x05 \x01(\tR\bstopTime\x12\x18\n" +
+	"\aretries\x18\x06 \x01(\x03R\aretries\x12%\n" +
+	"\x0eretry_interval\x18\a \x01(\x03R\rretryInterval\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1b\n" +
+	"\tfile_name\x18\t \x01(\tR\bfileName\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\tR\tupdatedAt\"O\n" +
+	"\x16GetDiagnosticsResponse\x125\n" +
+	"\arequest\x18\x01 \x01(\v2\x1b.command.DiagnosticsRequestR\arequest\"7\n" +
+	"\x16ListDiagnosticsRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\"\x87\x01\n" +
+	"\x0fDiagnosticsFile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\x03R\trequestId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\x82\x01\n" +
+	"\x17ListDiagnosticsResponse\x127\n" +
+	"\brequests\x18\x01 \x03(\v2\x1b.command.DiagnosticsRequestR\brequests\x12.\n" +
+	"\x05files\x18\x02 \x03(\v2\x18.command.DiagnosticsFileR\x05files\"T\n" +
+	"\x1aDownloadDiagnosticsRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\">\n" +
+	"\x14DiagnosticsFileChunk\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\xb1\x01\n" +
+	"\x11ReserveNowRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12!\n" +
+	"\fconnector_id\x18\x02 \x01(\x03R\vconnectorId\x12\x15\n" +
+	"\x06id_tag\x18\x03 \x01(\tR\x05idTag\x12\"\n" +
+	"\rparent_id_tag\x18\x04 \x01(\tR\vparentIdTag\x12\x1f\n" +
+	"\vexpiry_date\x18\x05 \x01(\tR\n" +
+	"expiryDate\"\xb0\x02\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x02 \x01(\x03R\tstationId\x12!\n" +
+	"\fconnector_id\x18\x03 \x01(\x03R\vconnectorId\x12\x15\n" +
+	"\x06id_tag\x18\x04 \x01(\tR\x05idTag\x12\"\n" +
+	"\rparent_id_tag\x18\x05 \x01(\tR\vparentIdTag\x12\x1f\n" +
+	"\vexpiry_date\x18\x06 \x01(\tR\n" +
+	"expiryDate\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\b \x01(\x03R\tsessionId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\"f\n" +
+	"\x12ReserveNowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x126\n" +
+	"\vreservation\x18\x02 \x01(\v2\x14.command.ReservationR\vreservation\"`\n" +
+	"\x18CancelReservationRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\x03R\rreservationId\"m\n" +
+	"\x19CancelReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x126\n" +
+	"\vreservation\x18\x02 \x01(\v2\x14.command.ReservationR\vreservation\"8\n" +
+	"\x17ListReservationsRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\"T\n" +
+	"\x18ListReservationsResponse\x128\n" +
+	"\freservations\x18\x01 \x03(\v2\x14.command.ReservationR\freservations\"\x80\x02\n" +
+	"\x0fSetIdTagRequest\x12\x15\n" +
+	"\x06id_tag\x18\x01 \x01(\tR\x05idTag\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\"\n" +
+	"\rparent_id_tag\x18\x03 \x01(\tR\vparentIdTag\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1f\n" +
+	"\vexpiry_date\x18\x05 \x01(\tR\n" +
+	"expiryDate\x12.\n" +
+	"\x13allowed_station_ids\x18\x06 \x03(\x03R\x11allowedStationIds\x120\n" +
+	"\x14allowed_location_ids\x18\a \x03(\x03R\x12allowedLocationIds\"\xaf\x02\n" +
+	"\x05IdTag\x12\x15\n" +
+	"\x06id_tag\x18\x01 \x01(\tR\x05idTag\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\"\n" +
+	"\rparent_id_tag\x18\x03 \x01(\tR\vparentIdTag\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1f\n" +
+	"\vexpiry_date\x18\x05 \x01(\tR\n" +
+	"expiryDate\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12.\n" +
+	"\x13allowed_station_ids\x18\b \x03(\x03R\x11allowedStationIds\x120\n" +
+	"\x14allowed_location_ids\x18\t \x03(\x03R\x12allowedLocationIds\"9\n" +
+	"\x10SetIdTagResponse\x12%\n" +
+	"\x06id_tag\x18\x01 \x01(\v2\x0e.command.IdTagR\x05idTag\"I\n" +
+	"\x14SyncLocalListRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\"K\n" +
+	"\x15SyncLocalListResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\";\n" +
+	"\x1aGetLocalListVersionRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\"\x90\x01\n" +
+	"\x1bGetLocalListVersionResponse\x12'\n" +
+	"\x0fstation_version\x18\x01 \x01(\x03R\x0estationVersion\x12%\n" +
+	"\x0esynced_version\x18\x02 \x01(\x03R\rsyncedVersion\x12!\n" +
+	"\flist_version\x18\x03 \x01(\x03R\vlistVersion\"2\n" +
+	"\x11ClearCacheRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\"F\n" +
+	"\x12ClearCacheResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"W\n" +
+	"\x1cSetAuthorizationCacheRequest\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\x03R\tstationId\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"z\n" +
+	"\x1dSetAuthorizationCacheResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12'\n" +
+	"\x0freboot_required\x18\x03 \x01(\bR\x0erebootRequired\"\x96\x02\n" +
+	"\x14SetIdTagGroupRequest\x12\"\n" +
+	"\rparent_id_tag\x18\x01 \x01(\tR\vparentIdTag\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x126\n" +
+	"\x17max_concurrent_sessions\x18\x03 \x01(\x03R\x15maxConcurrentSessions\x12,\n" +
+	"\x12monthly_energy_kwh\x18\x04 \x01(\x01R\x10monthlyEnergyKwh\x12.\n" +
+	"\x13allowed_station_ids\x18\x05 \x03(\x03R\x11allowedStationIds\x120\n" +
+	"\x14allowed_location_ids\x18\x06 \x03(\x03R\x12allowedLocationIds\":\n" +
+	"\x14GetIdTagGroupRequest\x12\"\n" +
+	"\rparent_id_tag\x18\x01 \x01(\tR\vparentIdTag\"\xab\x02\n" +
+	"\n" +
+	"IdTagGroup\x12\"\n" +
+	"\rparent_id_tag\x18\x01 \x01(\tR\vparentIdTag\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x126\n" +
+	"\x17max_concurrent_sessions\x18\x03 \x01(\x03R\x15maxConcurrentSessions\x12,\n" +
+	"\x12monthly_energy_kwh\x18\x04 \x01(\x01R\x10monthlyEnergyKwh\x12.\n" +
+	"\x13allowed_station_ids\x18\x05 \x03(\x03R\x11allowedStationIds\x120\n" +
+	"\x14allowed_location_ids\x18\x06 \x03(\x03R\x12allowedLocationIds\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\"\x92\x01\n" +
+	"\x12IdTagGroupResponse\x12)\n" +
+	"\x05group\x18\x01 \x01(\v2\x13.command.IdTagGroupR\x05group\x12'\n" +
+	"\x0factive_sessions\x18\x02 \x01(\x03R\x0eactiveSessions\x12(\n" +
+	"\x10month_energy_kwh\x18\x03 \x01(\x01R\x0emonthEnergyKwh*\x8b\x02\n" +
+	"\tErrorCode\x12\x10\n" +
+	"\ferrorUnknown\x10\x00\x12\v\n" +
+	"\aerrorDB\x10\x01\x12\x18\n" +
+	"\x13stationNotConnected\x10\x86\a\x12\x15\n" +
+	"\x10sendCommandError\x10\x87\a\x12\x1a\n" +
+	"\x15commandWasNotAccepted\x10\x88\a\x12\x15\n" +
+	"\x10commandCallError\x10\x89\a\x12\x1c\n" +
+	"\x17bootNotificationTimeout\x10\x8a\a\x12\x18\n" +
+	"\x13commandNotSupported\x10\x8b\a\x12\x1e\n" +
+	"\x19connectorHasActiveSession\x10\x8c\a\x12\r\n" +
+	"\bnotFound\x10\x8d\a\x12\x14\n" +
+	"\x0finvalidArgument\x10\x8e\a*\x1f\n" +
+	"\tResetType\x12\b\n" +
+	"\x04soft\x10\x00\x12\b\n" +
+	"\x04hard\x10\x01*2\n" +
+	"\x10AvailabilityType\x12\r\n" +
+	"\toperative\x10\x00\x12\x0f\n" +
+	"\vinoperative\x10\x01*\xa5\x01\n" +
+	"\x12TriggerMessageType\x12\x16\n" +
+	"\x12statusNotification\x10\x00\x12\x0f\n" +
+	"\vmeterValues\x10\x01\x12\r\n" +
+	"\theartbeat\x10\x02\x12\x14\n" +
+	"\x10bootNotification\x10\x03\x12!\n" +
+	"\x1ddiagnosticsStatusNotification\x10\x04\x12\x1e\n" +
+	"\x1afirmwareStatusNotification\x10\x052\x9f\x12\n" +
+	"\x0eControlService\x12D\n" +
+	"\x05Start\x12\x1c.command.StartStationRequest\x1a\x1d.command.StartStationResponse\x12A\n" +
+	"\x04Stop\x12\x1b.command.StopStationRequest\x1a\x1c.command.StopStationResponse\x12D\n" +
+	"\x05Reset\x12\x1c.command.ResetStationRequest\x1a\x1d.command.ResetStationResponse\x12]\n" +
+	"\x12ChangeAvailability\x12\".command.ChangeAvailabilityRequest\x1a#.command.ChangeAvailabilityResponse\x12W\n" +
+	"\x10GetConfiguration\x12 .command.GetConfigurationRequest\x1a!.command.GetConfigurationResponse\x12`\n" +
+	"\x13ChangeConfiguration\x12#.command.ChangeConfigurationRequest\x1a$.command.ChangeConfigurationResponse\x12f\n" +
+	"\x15GetConfigurationDrift\x12%.command.GetConfigurationDriftRequest\x1a&.command.GetConfigurationDriftResponse\x12T\n" +
+	"\x0fUnlockConnector\x12\x1f.command.UnlockConnectorRequest\x1a .command.UnlockConnectorResponse\x12Q\n" +
+	"\x0eTriggerMessage\x12\x1e.command.TriggerMessageRequest\x1a\x1f.command.TriggerMessageResponse\x12Q\n" +
+	"\x0eUpdateFirmware\x12\x1e.command.UpdateFirmwareRequest\x1a\x1f.command.UpdateFirmwareResponse\x12c\n" +
+	"\x16CreateFirmwareCampaign\x12&.command.CreateFirmwareCampaignRequest\x1a!.command.FirmwareCampaignResponse\x12Z\n" +
+	"\x13GetFirmwareCampaign\x12 .command.FirmwareCampaignRequest\x1a!.command.FirmwareCampaignResponse\x12\\\n" +
+	"\x15PauseFirmwareCampaign\x12 .command.FirmwareCampaignRequest\x1a!.command.FirmwareCampaignResponse\x12c\n" +
+	"\x16ResumeFirmwareCampaign\x12&.command.ResumeFirmwareCampaignRequest\x1a!.command.FirmwareCampaignResponse\x12Q\n" +
+	"\x0eGetDiagnostics\x12\x1e.command.GetDiagnosticsRequest\x1a\x1f.command.GetDiagnosticsResponse\x12T\n" +
+	"\x0fListDiagnostics\x12\x1f.command.ListDiagnosticsRequest\x1a .command.ListDiagnosticsResponse\x12[\n" +
+	"\x13DownloadDiagnostics\x12#.command.DownloadDiagnosticsRequest\x1a\x1d.command.DiagnosticsFileChunk0\x01\x12E\n" +
+	"\n" +
+	"ReserveNow\x12\x1a.command.ReserveNowRequest\x1a\x1b.command.ReserveNowResponse\x12Z\n" +
+	"\x11CancelReservation\x12!.command.CancelReservationRequest\x1a\".command.CancelReservationResponse\x12W\n" +
+	"\x10ListReservations\x12 .command.ListReservationsRequest\x1a!.command.ListReservationsResponse\x12?\n" +
+	"\bSetIdTag\x12\x18.command.SetIdTagRequest\x1a\x19.command.SetIdTagResponse\x12N\n" +
+	"\rSyncLocalList\x12\x1d.command.SyncLocalListRequest\x1a\x1e.command.SyncLocalListResponse\x12`\n" +
+	"\x13GetLocalListVersion\x12#.command.GetLocalListVersionRequest\x1a$.command.GetLocalListVersionResponse\x12E\n" +
+	"\n" +
+	"ClearCache\x12\x1a.command.ClearCacheRequest\x1a\x1b.command.ClearCacheResponse\x12f\n" +
+	"\x15SetAuthorizationCache\x12%.command.SetAuthorizationCacheRequest\x1a&.command.SetAuthorizationCacheResponse\x12K\n" +
+	"\rSetIdTagGroup\x12\x1d.command.SetIdTagGroupRequest\x1a\x1b.command.IdTagGroupResponse\x12K\n" +
+	"\rGetIdTagGroup\x12\x1d.command.GetIdTagGroupRequest\x1a\x1b.command.IdTagGroupResponseB\vZ\t.;controlb\x06proto3"
 
 var (
 	file_internal_proto_control_control_proto_rawDescOnce sync.Once
@@ -428,27 +4463,150 @@ func file_internal_proto_control_control_proto_rawDescGZIP() []byte {
 	return file_internal_proto_control_control_proto_rawDescData
 }
 
-var file_internal_proto_control_control_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_proto_control_control_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_internal_proto_control_control_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_internal_proto_control_control_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_internal_proto_control_control_proto_goTypes = []any{
-	(ErrorCode)(0),               // 0: command.ErrorCode
-	(*CustomErrorDetail)(nil),    // 1: command.CustomErrorDetail
-	(*CommandResponse)(nil),      // 2: command.CommandResponse
-	(*StartStationRequest)(nil),  // 3: command.StartStationRequest
-	(*StartStationResponse)(nil), // 4: command.StartStationResponse
-	(*StopStationRequest)(nil),   // 5: command.StopStationRequest
-	(*StopStationResponse)(nil),  // 6: command.StopStationResponse
+	(ErrorCode)(0),                        // 0: command.ErrorCode
+	(ResetType)(0),                        // 1: command.ResetType
+	(AvailabilityType)(0),                 // 2: command.AvailabilityType
+	(TriggerMessageType)(0),               // 3: command.TriggerMessageType
+	(*CustomErrorDetail)(nil),             // 4: command.CustomErrorDetail
+	(*OcppErrorDetail)(nil),               // 5: command.OcppErrorDetail
+	(*CommandResponse)(nil),               // 6: command.CommandResponse
+	(*StartStationRequest)(nil),           // 7: command.StartStationRequest
+	(*StartStationResponse)(nil),          // 8: command.StartStationResponse
+	(*StopStationRequest)(nil),            // 9: command.StopStationRequest
+	(*StopStationResponse)(nil),           // 10: command.StopStationResponse
+	(*ResetStationRequest)(nil),           // 11: command.ResetStationRequest
+	(*ResetStationResponse)(nil),          // 12: command.ResetStationResponse
+	(*ChangeAvailabilityRequest)(nil),     // 13: command.ChangeAvailabilityRequest
+	(*ChangeAvailabilityResponse)(nil),    // 14: command.ChangeAvailabilityResponse
+	(*ConfigurationKey)(nil),              // 15: command.ConfigurationKey
+	(*GetConfigurationRequest)(nil),       // 16: command.GetConfigurationRequest
+	(*GetConfigurationResponse)(nil),      // 17: command.GetConfigurationResponse
+	(*ChangeConfigurationRequest)(nil),    // 18: command.ChangeConfigurationRequest
+	(*ChangeConfigurationResponse)(nil),   // 19: command.ChangeConfigurationResponse
+	(*GetConfigurationDriftRequest)(nil),  // 20: command.GetConfigurationDriftRequest
+	(*ConfigurationDrift)(nil),            // 21: command.ConfigurationDrift
+	(*GetConfigurationDriftResponse)(nil), // 22: command.GetConfigurationDriftResponse
+	(*UnlockConnectorRequest)(nil),        // 23: command.UnlockConnectorRequest
+	(*UnlockConnectorResponse)(nil),       // 24: command.UnlockConnectorResponse
+	(*TriggerMessageRequest)(nil),         // 25: command.TriggerMessageRequest
+	(*TriggerMessageResponse)(nil),        // 26: command.TriggerMessageResponse
+	(*UpdateFirmwareRequest)(nil),         // 27: command.UpdateFirmwareRequest
+	(*UpdateFirmwareResponse)(nil),        // 28: command.UpdateFirmwareResponse
+	(*CreateFirmwareCampaignRequest)(nil), // 29: command.CreateFirmwareCampaignRequest
+	(*FirmwareCampaignRequest)(nil),       // 30: command.FirmwareCampaignRequest
+	(*ResumeFirmwareCampaignRequest)(nil), // 31: command.ResumeFirmwareCampaignRequest
+	(*FirmwareCampaign)(nil),              // 32: command.FirmwareCampaign
+	(*FirmwareCampaignStation)(nil),       // 33: command.FirmwareCampaignStation
+	(*FirmwareCampaignResponse)(nil),      // 34: command.FirmwareCampaignResponse
+	(*GetDiagnosticsRequest)(nil),         // 35: command.GetDiagnosticsRequest
+	(*DiagnosticsRequest)(nil),            // 36: command.DiagnosticsRequest
+	(*GetDiagnosticsResponse)(nil),        // 37: command.GetDiagnosticsResponse
+	(*ListDiagnosticsRequest)(nil),        // 38: command.ListDiagnosticsRequest
+	(*DiagnosticsFile)(nil),               // 39: command.DiagnosticsFile
+	(*ListDiagnosticsResponse)(nil),       // 40: command.ListDiagnosticsResponse
+	(*DownloadDiagnosticsRequest)(nil),    // 41: command.DownloadDiagnosticsRequest
+	(*DiagnosticsFileChunk)(nil),          // 42: command.DiagnosticsFileChunk
+	(*ReserveNowRequest)(nil),             // 43: command.ReserveNowRequest
+	(*Reservation)(nil),                   // 44: command.Reservation
+	(*ReserveNowResponse)(nil),            // 45: command.ReserveNowResponse
+	(*CancelReservationRequest)(nil),      // 46: command.CancelReservationRequest
+	(*CancelReservationResponse)(nil),     // 47: command.CancelReservationResponse
+	(*ListReservationsRequest)(nil),       // 48: command.ListReservationsRequest
+	(*ListReservationsResponse)(nil),      // 49: command.ListReservationsResponse
+	(*SetIdTagRequest)(nil),               // 50: command.SetIdTagRequest
+	(*IdTag)(nil),                         // 51: command.IdTag
+	(*SetIdTagResponse)(nil),              // 52: command.SetIdTagResponse
+	(*SyncLocalListRequest)(nil),          // 53: command.SyncLocalListRequest
+	(*SyncLocalListResponse)(nil),         // 54: command.SyncLocalListResponse
+	(*GetLocalListVersionRequest)(nil),    // 55: command.GetLocalListVersionRequest
+	(*GetLocalListVersionResponse)(nil),   // 56: command.GetLocalListVersionResponse
+	(*ClearCacheRequest)(nil),             // 57: command.ClearCacheRequest
+	(*ClearCacheResponse)(nil),            // 58: command.ClearCacheResponse
+	(*SetAuthorizationCacheRequest)(nil),  // 59: command.SetAuthorizationCacheRequest
+	(*SetAuthorizationCacheResponse)(nil), // 60: command.SetAuthorizationCacheResponse
+	(*SetIdTagGroupRequest)(nil),          // 61: command.SetIdTagGroupRequest
+	(*GetIdTagGroupRequest)(nil),          // 62: command.GetIdTagGroupRequest
+	(*IdTagGroup)(nil),                    // 63: command.IdTagGroup
+	(*IdTagGroupResponse)(nil),            // 64: command.IdTagGroupResponse
 }
 var file_internal_proto_control_control_proto_depIdxs = []int32{
-	3, // 0: command.ControlService.Start:input_type -> command.StartStationRequest
-	5, // 1: command.ControlService.Stop:input_type -> command.StopStationRequest
-	4, // 2: command.ControlService.Start:output_type -> command.StartStationResponse
-	6, // 3: command.ControlService.Stop:output_type -> command.StopStationResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1,  // 0: command.ResetStationRequest.type:type_name -> command.ResetType
+	2,  // 1: command.ChangeAvailabilityRequest.type:type_name -> command.AvailabilityType
+	15, // 2: command.GetConfigurationResponse.configuration_keys:type_name -> command.ConfigurationKey
+	21, // 3: command.GetConfigurationDriftResponse.drift:type_name -> command.ConfigurationDrift
+	3,  // 4: command.TriggerMessageRequest.requested_message:type_name -> command.TriggerMessageType
+	32, // 5: command.FirmwareCampaignResponse.campaign:type_name -> command.FirmwareCampaign
+	33, // 6: command.FirmwareCampaignResponse.stations:type_name -> command.FirmwareCampaignStation
+	36, // 7: command.GetDiagnosticsResponse.request:type_name -> command.DiagnosticsRequest
+	36, // 8: command.ListDiagnosticsResponse.requests:type_name -> command.DiagnosticsRequest
+	39, // 9: command.ListDiagnosticsResponse.files:type_name -> command.DiagnosticsFile
+	44, // 10: command.ReserveNowResponse.reservation:type_name -> command.Reservation
+	44, // 11: command.CancelReservationResponse.reservation:type_name -> command.Reservation
+	44, // 12: command.ListReservationsResponse.reservations:type_name -> command.Reservation
+	51, // 13: command.SetIdTagResponse.id_tag:type_name -> command.IdTag
+	63, // 14: command.IdTagGroupResponse.group:type_name -> command.IdTagGroup
+	7,  // 15: command.ControlService.Start:input_type -> command.StartStationRequest
+	9,  // 16: command.ControlService.Stop:input_type -> command.StopStationRequest
+	11, // 17: command.ControlService.Reset:input_type -> command.ResetStationRequest
+	13, // 18: command.ControlService.ChangeAvailability:input_type -> command.ChangeAvailabilityRequest
+	16, // 19: command.ControlService.GetConfiguration:input_type -> command.GetConfigurationRequest
+	18, // 20: command.ControlService.ChangeConfiguration:input_type -> command.ChangeConfigurationRequest
+	20, // 21: command.ControlService.GetConfigurationDrift:input_type -> command.GetConfigurationDriftRequest
+	23, // 22: command.ControlService.UnlockConnector:input_type -> command.UnlockConnectorRequest
+	25, // 23: command.ControlService.TriggerMessage:input_type -> command.TriggerMessageRequest
+	27, // 24: command.ControlService.UpdateFirmware:input_type -> command.UpdateFirmwareRequest
+	29, // 25: command.ControlService.CreateFirmwareCampaign:input_type -> command.CreateFirmwareCampaignRequest
+	30, // 26: command.ControlService.GetFirmwareCampaign:input_type -> command.FirmwareCampaignRequest
+	30, // 27: command.ControlService.PauseFirmwareCampaign:input_type -> command.FirmwareCampaignRequest
+	31, // 28: command.ControlService.ResumeFirmwareCampaign:input_type -> command.ResumeFirmwareCampaignRequest
+	35, // 29: command.ControlService.GetDiagnostics:input_type -> command.GetDiagnosticsRequest
+	38, // 30: command.ControlService.ListDiagnostics:input_type -> command.ListDiagnosticsRequest
+	41, // 31: command.ControlService.DownloadDiagnostics:input_type -> command.DownloadDiagnosticsRequest
+	43, // 32: command.ControlService.ReserveNow:input_type -> command.ReserveNowRequest
+	46, // 33: command.ControlService.CancelReservation:input_type -> command.CancelReservationRequest
+	48, // 34: command.ControlService.ListReservations:input_type -> command.ListReservationsRequest
+	50, // 35: command.ControlService.SetIdTag:input_type -> command.SetIdTagRequest
+	53, // 36: command.ControlService.SyncLocalList:input_type -> command.SyncLocalListRequest
+	55, // 37: command.ControlService.GetLocalListVersion:input_type -> command.GetLocalListVersionRequest
+	57, // 38: command.ControlService.ClearCache:input_type -> command.ClearCacheRequest
+	59, // 39: command.ControlService.SetAuthorizationCache:input_type -> command.SetAuthorizationCacheRequest
+	61, // 40: command.ControlService.SetIdTagGroup:input_type -> command.SetIdTagGroupRequest
+	62, // 41: command.ControlService.GetIdTagGroup:input_type -> command.GetIdTagGroupRequest
+	8,  // 42: command.ControlService.Start:output_type -> command.StartStationResponse
+	10, // 43: command.ControlService.Stop:output_type -> command.StopStationResponse
+	12, // 44: command.ControlService.Reset:output_type -> command.ResetStationResponse
+	14, // 45: command.ControlService.ChangeAvailability:output_type -> command.ChangeAvailabilityResponse
+	17, // 46: command.ControlService.GetConfiguration:output_type -> command.GetConfigurationResponse
+	19, // 47: command.ControlService.ChangeConfiguration:output_type -> command.ChangeConfigurationResponse
+	22, // 48: command.ControlService.GetConfigurationDrift:output_type -> command.GetConfigurationDriftResponse
+	24, // 49: command.ControlService.UnlockConnector:output_type -> command.UnlockConnectorResponse
+	26, // 50: command.ControlService.TriggerMessage:output_type -> command.TriggerMessageResponse
+	28, // 51: command.ControlService.UpdateFirmware:output_type -> command.UpdateFirmwareResponse
+	34, // 52: command.ControlService.CreateFirmwareCampaign:output_type -> command.FirmwareCampaignResponse
+	34, // 53: command.ControlService.GetFirmwareCampaign:output_type -> command.FirmwareCampaignResponse
+	34, // 54: command.ControlService.PauseFirmwareCampaign:output_type -> command.FirmwareCampaignResponse
+	34, // 55: command.ControlService.ResumeFirmwareCampaign:output_type -> command.FirmwareCampaignResponse
+	37, // 56: command.ControlService.GetDiagnostics:output_type -> command.GetDiagnosticsResponse
+	40, // 57: command.ControlService.ListDiagnostics:output_type -> command.ListDiagnosticsResponse
+	42, // 58: command.ControlService.DownloadDiagnostics:output_type -> command.DiagnosticsFileChunk
+	45, // 59: command.ControlService.ReserveNow:output_type -> command.ReserveNowResponse
+	47, // 60: command.ControlService.CancelReservation:output_type -> command.CancelReservationResponse
+	49, // 61: command.ControlService.ListReservations:output_type -> command.ListReservationsResponse
+	52, // 62: command.ControlService.SetIdTag:output_type -> command.SetIdTagResponse
+	54, // 63: command.ControlService.SyncLocalList:output_type -> command.SyncLocalListResponse
+	56, // 64: command.ControlService.GetLocalListVersion:output_type -> command.GetLocalListVersionResponse
+	58, // 65: command.ControlService.ClearCache:output_type -> command.ClearCacheResponse
+	60, // 66: command.ControlService.SetAuthorizationCache:output_type -> command.SetAuthorizationCacheResponse
+	64, // 67: command.ControlService.SetIdTagGroup:output_type -> command.IdTagGroupResponse
+	64, // 68: command.ControlService.GetIdTagGroup:output_type -> command.IdTagGroupResponse
+	42, // [42:69] is the sub-list for method output_type
+	15, // [15:42] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_control_control_proto_init() }
@@ -461,8 +4619,8 @@ func file_internal_proto_control_control_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_control_control_proto_rawDesc), len(file_internal_proto_control_control_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      4,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,33 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ControlService_Start_FullMethodName = "/command.ControlService/Start"
-	ControlService_Stop_FullMethodName  = "/command.ControlService/Stop"
+	ControlService_Start_FullMethodName                  = "/command.ControlService/Start"
+	ControlService_Stop_FullMethodName                   = "/command.ControlService/Stop"
+	ControlService_Reset_FullMethodName                  = "/command.ControlService/Reset"
+	ControlService_ChangeAvailability_FullMethodName     = "/command.ControlService/ChangeAvailability"
+	ControlService_GetConfiguration_FullMethodName       = "/command.ControlService/GetConfiguration"
+	ControlService_ChangeConfiguration_FullMethodName    = "/command.ControlService/ChangeConfiguration"
+	ControlService_GetConfigurationDrift_FullMethodName  = "/command.ControlService/GetConfigurationDrift"
+	ControlService_UnlockConnector_FullMethodName        = "/command.ControlService/UnlockConnector"
+	ControlService_TriggerMessage_FullMethodName         = "/command.ControlService/TriggerMessage"
+	ControlService_UpdateFirmware_FullMethodName         = "/command.ControlService/UpdateFirmware"
+	ControlService_CreateFirmwareCampaign_FullMethodName = "/command.ControlService/CreateFirmwareCampaign"
+	ControlService_GetFirmwareCampaign_FullMethodName    = "/command.ControlService/GetFirmwareCampaign"
+	ControlService_PauseFirmwareCampaign_FullMethodName  = "/command.ControlService/PauseFirmwareCampaign"
+	ControlService_ResumeFirmwareCampaign_FullMethodName = "/command.ControlService/ResumeFirmwareCampaign"
+	ControlService_GetDiagnostics_FullMethodName         = "/command.ControlService/GetDiagnostics"
+	ControlService_ListDiagnostics_FullMethodName        = "/command.ControlService/ListDiagnostics"
+	ControlService_DownloadDiagnostics_FullMethodName    = "/command.ControlService/DownloadDiagnostics"
+	ControlService_ReserveNow_FullMethodName             = "/command.ControlService/ReserveNow"
+	ControlService_CancelReservation_FullMethodName      = "/command.ControlService/CancelReservation"
+	ControlService_ListReservations_FullMethodName       = "/command.ControlService/ListReservations"
+	ControlService_SetIdTag_FullMethodName               = "/command.ControlService/SetIdTag"
+	ControlService_SyncLocalList_FullMethodName          = "/command.ControlService/SyncLocalList"
+	ControlService_GetLocalListVersion_FullMethodName    = "/command.ControlService/GetLocalListVersion"
+	ControlService_ClearCache_FullMethodName             = "/command.ControlService/ClearCache"
+	ControlService_SetAuthorizationCache_FullMethodName  = "/command.ControlService/SetAuthorizationCache"
+	ControlService_SetIdTagGroup_FullMethodName          = "/command.ControlService/SetIdTagGroup"
+	ControlService_GetIdTagGroup_FullMethodName          = "/command.ControlService/GetIdTagGroup"
 )
 
 // ControlServiceClient is the client API for ControlService service.
@@ -29,6 +54,31 @@ const (
 type ControlServiceClient interface {
 	Start(ctx context.Context, in *StartStationRequest, opts ...grpc.CallOption) (*StartStationResponse, error)
 	Stop(ctx context.Context, in *StopStationRequest, opts ...grpc.CallOption) (*StopStationResponse, error)
+	Reset(ctx context.Context, in *ResetStationRequest, opts ...grpc.CallOption) (*ResetStationResponse, error)
+	ChangeAvailability(ctx context.Context, in *ChangeAvailabilityRequest, opts ...grpc.CallOption) (*ChangeAvailabilityResponse, error)
+	GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*GetConfigurationResponse, error)
+	ChangeConfiguration(ctx context.Context, in *ChangeConfigurationRequest, opts ...grpc.CallOption) (*ChangeConfigurationResponse, error)
+	GetConfigurationDrift(ctx context.Context, in *GetConfigurationDriftRequest, opts ...grpc.CallOption) (*GetConfigurationDriftResponse, error)
+	UnlockConnector(ctx context.Context, in *UnlockConnectorRequest, opts ...grpc.CallOption) (*UnlockConnectorResponse, error)
+	TriggerMessage(ctx context.Context, in *TriggerMessageRequest, opts ...grpc.CallOption) (*TriggerMessageResponse, error)
+	UpdateFirmware(ctx context.Context, in *UpdateFirmwareRequest, opts ...grpc.CallOption) (*UpdateFirmwareResponse, error)
+	CreateFirmwareCampaign(ctx context.Context, in *CreateFirmwareCampaignRequest, opts ...grpc.CallOption) (*FirmwareCampaignResponse, error)
+	GetFirmwareCampaign(ctx context.Context, in *FirmwareCampaignRequest, opts ...grpc.CallOption) (*FirmwareCampaignResponse, error)
+	PauseFirmwareCampaign(ctx context.Context, in *FirmwareCampaignRequest, opts ...grpc.CallOption) (*FirmwareCampaignResponse, error)
+	ResumeFirmwareCampaign(ctx context.Context, in *ResumeFirmwareCampaignRequest, opts ...grpc.CallOption) (*FirmwareCampaignResponse, error)
+	GetDiagnostics(ctx context.Context, in *GetDiagnosticsRequest, opts ...grpc.CallOption) (*GetDiagnosticsResponse, error)
+	ListDiagnostics(ctx context.Context, in *ListDiagnosticsRequest, opts ...grpc.CallOption) (*ListDiagnosticsResponse, error)
+	DownloadDiagnostics(ctx context.Context, in *DownloadDiagnosticsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DiagnosticsFileChunk], error)
+	ReserveNow(ctx context.Context, in *ReserveNowRequest, opts ...grpc.CallOption) (*ReserveNowResponse, error)
+	CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationResponse, error)
+	ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error)
+	SetIdTag(ctx context.Context, in *SetIdTagRequest, opts ...grpc.CallOption) (*SetIdTagResponse, error)
+	SyncLocalList(ctx context.Context, in *SyncLocalListRequest, opts ...grpc.CallOption) (*SyncLocalListResponse, error)
+	GetLocalListVersion(ctx context.Context, in *GetLocalListVersionRequest, opts ...grpc.CallOption) (*GetLocalListVersionResponse, error)
+	ClearCache(ctx context.Context, in *ClearCacheRequest, opts ...grpc.CallOption) (*ClearCacheResponse, error)
+	SetAuthorizationCache(ctx context.Context, in *SetAuthorizationCacheRequest, opts ...grpc.CallOption) (*SetAuthorizationCacheResponse, error)
+	SetIdTagGroup(ctx context.Context, in *SetIdTagGroupRequest, opts ...grpc.CallOption) (*IdTagGroupResponse, error)
+	GetIdTagGroup(ctx context.Context, in *GetIdTagGroupRequest, opts ...grpc.CallOption) (*IdTagGroupResponse, error)
 }
 
 type controlServiceClient struct {
@@ -59,12 +109,296 @@ func (c *controlServiceClient) Stop(ctx context.Context, in *StopStationRequest,
 	return out, nil
 }
 
+func (c *controlServiceClient) Reset(ctx context.Context, in *ResetStationRequest, opts ...grpc.CallOption) (*ResetStationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetStationResponse)
+	err := c.cc.Invoke(ctx, ControlService_Reset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) ChangeAvailability(ctx context.Context, in *ChangeAvailabilityRequest, opts ...grpc.CallOption) (*ChangeAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeAvailabilityResponse)
+	err := c.cc.Invoke(ctx, ControlService_ChangeAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*GetConfigurationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigurationResponse)
+	err := c.cc.Invoke(ctx, ControlService_GetConfiguration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) ChangeConfiguration(ctx context.Context, in *ChangeConfigurationRequest, opts ...grpc.CallOption) (*ChangeConfigurationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeConfigurationResponse)
+	err := c.cc.Invoke(ctx, ControlService_ChangeConfiguration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) GetConfigurationDrift(ctx context.Context, in *GetConfigurationDriftRequest, opts ...grpc.CallOption) (*GetConfigurationDriftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigurationDriftResponse)
+	err := c.cc.Invoke(ctx, ControlService_GetConfigurationDrift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) UnlockConnector(ctx context.Context, in *UnlockConnectorRequest, opts ...grpc.CallOption) (*UnlockConnectorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockConnectorResponse)
+	err := c.cc.Invoke(ctx, ControlService_UnlockConnector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) TriggerMessage(ctx context.Context, in *TriggerMessageRequest, opts ...grpc.CallOption) (*TriggerMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriggerMessageResponse)
+	err := c.cc.Invoke(ctx, ControlService_TriggerMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) UpdateFirmware(ctx context.Context, in *UpdateFirmwareRequest, opts ...grpc.CallOption) (*UpdateFirmwareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateFirmwareResponse)
+	err := c.cc.Invoke(ctx, ControlService_UpdateFirmware_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) CreateFirmwareCampaign(ctx context.Context, in *CreateFirmwareCampaignRequest, opts ...grpc.CallOption) (*FirmwareCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FirmwareCampaignResponse)
+	err := c.cc.Invoke(ctx, ControlService_CreateFirmwareCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) GetFirmwareCampaign(ctx context.Context, in *FirmwareCampaignRequest, opts ...grpc.CallOption) (*FirmwareCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FirmwareCampaignResponse)
+	err := c.cc.Invoke(ctx, ControlService_GetFirmwareCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) PauseFirmwareCampaign(ctx context.Context, in *FirmwareCampaignRequest, opts ...grpc.CallOption) (*FirmwareCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FirmwareCampaignResponse)
+	err := c.cc.Invoke(ctx, ControlService_PauseFirmwareCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) ResumeFirmwareCampaign(ctx context.Context, in *ResumeFirmwareCampaignRequest, opts ...grpc.CallOption) (*FirmwareCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FirmwareCampaignResponse)
+	err := c.cc.Invoke(ctx, ControlService_ResumeFirmwareCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) GetDiagnostics(ctx context.Context, in *GetDiagnosticsRequest, opts ...grpc.CallOption) (*GetDiagnosticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDiagnosticsResponse)
+	err := c.cc.Invoke(ctx, ControlService_GetDiagnostics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) ListDiagnostics(ctx context.Context, in *ListDiagnosticsRequest, opts ...grpc.CallOption) (*ListDiagnosticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDiagnosticsResponse)
+	err := c.cc.Invoke(ctx, ControlService_ListDiagnostics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) DownloadDiagnostics(ctx context.Context, in *DownloadDiagnosticsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DiagnosticsFileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ControlService_ServiceDesc.Streams[0], ControlService_DownloadDiagnostics_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadDiagnosticsRequest, DiagnosticsFileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlService_DownloadDiagnosticsClient = grpc.ServerStreamingClient[DiagnosticsFileChunk]
+
+func (c *controlServiceClient) ReserveNow(ctx context.Context, in *ReserveNowRequest, opts ...grpc.CallOption) (*ReserveNowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveNowResponse)
+	err := c.cc.Invoke(ctx, ControlService_ReserveNow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelReservationResponse)
+	err := c.cc.Invoke(ctx, ControlService_CancelReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReservationsResponse)
+	err := c.cc.Invoke(ctx, ControlService_ListReservations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) SetIdTag(ctx context.Context, in *SetIdTagRequest, opts ...grpc.CallOption) (*SetIdTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetIdTagResponse)
+	err := c.cc.Invoke(ctx, ControlService_SetIdTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) SyncLocalList(ctx context.Context, in *SyncLocalListRequest, opts ...grpc.CallOption) (*SyncLocalListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncLocalListResponse)
+	err := c.cc.Invoke(ctx, ControlService_SyncLocalList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) GetLocalListVersion(ctx context.Context, in *GetLocalListVersionRequest, opts ...grpc.CallOption) (*GetLocalListVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLocalListVersionResponse)
+	err := c.cc.Invoke(ctx, ControlService_GetLocalListVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) ClearCache(ctx context.Context, in *ClearCacheRequest, opts ...grpc.CallOption) (*ClearCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearCacheResponse)
+	err := c.cc.Invoke(ctx, ControlService_ClearCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) SetAuthorizationCache(ctx context.Context, in *SetAuthorizationCacheRequest, opts ...grpc.CallOption) (*SetAuthorizationCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAuthorizationCacheResponse)
+	err := c.cc.Invoke(ctx, ControlService_SetAuthorizationCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) SetIdTagGroup(ctx context.Context, in *SetIdTagGroupRequest, opts ...grpc.CallOption) (*IdTagGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IdTagGroupResponse)
+	err := c.cc.Invoke(ctx, ControlService_SetIdTagGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) GetIdTagGroup(ctx context.Context, in *GetIdTagGroupRequest, opts ...grpc.CallOption) (*IdTagGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IdTagGroupResponse)
+	err := c.cc.Invoke(ctx, ControlService_GetIdTagGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ControlServiceServer is the server API for ControlService service.
 // All implementations must embed UnimplementedControlServiceServer
 // for forward compatibility.
 type ControlServiceServer interface {
 	Start(context.Context, *StartStationRequest) (*StartStationResponse, error)
 	Stop(context.Context, *StopStationRequest) (*StopStationResponse, error)
+	Reset(context.Context, *ResetStationRequest) (*ResetStationResponse, error)
+	ChangeAvailability(context.Context, *ChangeAvailabilityRequest) (*ChangeAvailabilityResponse, error)
+	GetConfiguration(context.Context, *GetConfigurationRequest) (*GetConfigurationResponse, error)
+	ChangeConfiguration(context.Context, *ChangeConfigurationRequest) (*ChangeConfigurationResponse, error)
+	GetConfigurationDrift(context.Context, *GetConfigurationDriftRequest) (*GetConfigurationDriftResponse, error)
+	UnlockConnector(context.Context, *UnlockConnectorRequest) (*UnlockConnectorResponse, error)
+	TriggerMessage(context.Context, *TriggerMessageRequest) (*TriggerMessageResponse, error)
+	UpdateFirmware(context.Context, *UpdateFirmwareRequest) (*UpdateFirmwareResponse, error)
+	CreateFirmwareCampaign(context.Context, *CreateFirmwareCampaignRequest) (*FirmwareCampaignResponse, error)
+	GetFirmwareCampaign(context.Context, *FirmwareCampaignRequest) (*FirmwareCampaignResponse, error)
+	PauseFirmwareCampaign(context.Context, *FirmwareCampaignRequest) (*FirmwareCampaignResponse, error)
+	ResumeFirmwareCampaign(context.Context, *ResumeFirmwareCampaignRequest) (*FirmwareCampaignResponse, error)
+	GetDiagnostics(context.Context, *GetDiagnosticsRequest) (*GetDiagnosticsResponse, error)
+	ListDiagnostics(context.Context, *ListDiagnosticsRequest) (*ListDiagnosticsResponse, error)
+	DownloadDiagnostics(*DownloadDiagnosticsRequest, grpc.ServerStreamingServer[DiagnosticsFileChunk]) error
+	ReserveNow(context.Context, *ReserveNowRequest) (*ReserveNowResponse, error)
+	CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationResponse, error)
+	ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+	SetIdTag(context.Context, *SetIdTagRequest) (*SetIdTagResponse, error)
+	SyncLocalList(context.Context, *SyncLocalListRequest) (*SyncLocalListResponse, error)
+	GetLocalListVersion(context.Context, *GetLocalListVersionRequest) (*GetLocalListVersionResponse, error)
+	ClearCache(context.Context, *ClearCacheRequest) (*ClearCacheResponse, error)
+	SetAuthorizationCache(context.Context, *SetAuthorizationCacheRequest) (*SetAuthorizationCacheResponse, error)
+	SetIdTagGroup(context.Context, *SetIdTagGroupRequest) (*IdTagGroupResponse, error)
+	GetIdTagGroup(context.Context, *GetIdTagGroupRequest) (*IdTagGroupResponse, error)
 	mustEmbedUnimplementedControlServiceServer()
 }
 
@@ -81,6 +415,81 @@ func (UnimplementedControlServiceServer) Start(context.Context, *StartStationReq
 func (UnimplementedControlServiceServer) Stop(context.Context, *StopStationRequest) (*StopStationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (UnimplementedControlServiceServer) Reset(context.Context, *ResetStationRequest) (*ResetStationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+func (UnimplementedControlServiceServer) ChangeAvailability(context.Context, *ChangeAvailabilityRequest) (*ChangeAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeAvailability not implemented")
+}
+func (UnimplementedControlServiceServer) GetConfiguration(context.Context, *GetConfigurationRequest) (*GetConfigurationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguration not implemented")
+}
+func (UnimplementedControlServiceServer) ChangeConfiguration(context.Context, *ChangeConfigurationRequest) (*ChangeConfigurationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeConfiguration not implemented")
+}
+func (UnimplementedControlServiceServer) GetConfigurationDrift(context.Context, *GetConfigurationDriftRequest) (*GetConfigurationDriftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigurationDrift not implemented")
+}
+func (UnimplementedControlServiceServer) UnlockConnector(context.Context, *UnlockConnectorRequest) (*UnlockConnectorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockConnector not implemented")
+}
+func (UnimplementedControlServiceServer) TriggerMessage(context.Context, *TriggerMessageRequest) (*TriggerMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerMessage not implemented")
+}
+func (UnimplementedControlServiceServer) UpdateFirmware(context.Context, *UpdateFirmwareRequest) (*UpdateFirmwareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFirmware not implemented")
+}
+func (UnimplementedControlServiceServer) CreateFirmwareCampaign(context.Context, *CreateFirmwareCampaignRequest) (*FirmwareCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFirmwareCampaign not implemented")
+}
+func (UnimplementedControlServiceServer) GetFirmwareCampaign(context.Context, *FirmwareCampaignRequest) (*FirmwareCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFirmwareCampaign not implemented")
+}
+func (UnimplementedControlServiceServer) PauseFirmwareCampaign(context.Context, *FirmwareCampaignRequest) (*FirmwareCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseFirmwareCampaign not implemented")
+}
+func (UnimplementedControlServiceServer) ResumeFirmwareCampaign(context.Context, *ResumeFirmwareCampaignRequest) (*FirmwareCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeFirmwareCampaign not implemented")
+}
+func (UnimplementedControlServiceServer) GetDiagnostics(context.Context, *GetDiagnosticsRequest) (*GetDiagnosticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiagnostics not implemented")
+}
+func (UnimplementedControlServiceServer) ListDiagnostics(context.Context, *ListDiagnosticsRequest) (*ListDiagnosticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDiagnostics not implemented")
+}
+func (UnimplementedControlServiceServer) DownloadDiagnostics(*DownloadDiagnosticsRequest, grpc.ServerStreamingServer[DiagnosticsFileChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadDiagnostics not implemented")
+}
+func (UnimplementedControlServiceServer) ReserveNow(context.Context, *ReserveNowRequest) (*ReserveNowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveNow not implemented")
+}
+func (UnimplementedControlServiceServer) CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (UnimplementedControlServiceServer) ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
+}
+func (UnimplementedControlServiceServer) SetIdTag(context.Context, *SetIdTagRequest) (*SetIdTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIdTag not implemented")
+}
+func (UnimplementedControlServiceServer) SyncLocalList(context.Context, *SyncLocalListRequest) (*SyncLocalListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncLocalList not implemented")
+}
+func (UnimplementedControlServiceServer) GetLocalListVersion(context.Context, *GetLocalListVersionRequest) (*GetLocalListVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLocalListVersion not implemented")
+}
+func (UnimplementedControlServiceServer) ClearCache(context.Context, *ClearCacheRequest) (*ClearCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCache not implemented")
+}
+func (UnimplementedControlServiceServer) SetAuthorizationCache(context.Context, *SetAuthorizationCacheRequest) (*SetAuthorizationCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAuthorizationCache not implemented")
+}
+func (UnimplementedControlServiceServer) SetIdTagGroup(context.Context, *SetIdTagGroupRequest) (*IdTagGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIdTagGroup not implemented")
+}
+func (UnimplementedControlServiceServer) GetIdTagGroup(context.Context, *GetIdTagGroupRequest) (*IdTagGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdTagGroup not implemented")
+}
 func (UnimplementedControlServiceServer) mustEmbedUnimplementedControlServiceServer() {}
 func (UnimplementedControlServiceServer) testEmbeddedByValue()                        {}
 
@@ -138,22 +547,567 @@ func _ControlService_Stop_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
-// ControlService_ServiceDesc is the grpc.ServiceDesc for ControlService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var ControlService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "command.ControlService",
-	HandlerType: (*ControlServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Start",
-			Handler:    _ControlService_Start_Handler,
-		},
-		{
-			MethodName: "Stop",
-			Handler:    _ControlService_Stop_Handler,
+func _ControlService_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetStationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_Reset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).Reset(ctx, req.(*ResetStationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_ChangeAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ChangeAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ChangeAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ChangeAvailability(ctx, req.(*ChangeAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_GetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).GetConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_GetConfiguration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).GetConfiguration(ctx, req.(*GetConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_ChangeConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ChangeConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ChangeConfiguration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ChangeConfiguration(ctx, req.(*ChangeConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_GetConfigurationDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigurationDriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).GetConfigurationDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_GetConfigurationDrift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).GetConfigurationDrift(ctx, req.(*GetConfigurationDriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_UnlockConnector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockConnectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).UnlockConnector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_UnlockConnector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).UnlockConnector(ctx, req.(*UnlockConnectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_TriggerMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).TriggerMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_TriggerMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).TriggerMessage(ctx, req.(*TriggerMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_UpdateFirmware_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFirmwareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).UpdateFirmware(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_UpdateFirmware_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).UpdateFirmware(ctx, req.(*UpdateFirmwareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_CreateFirmwareCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFirmwareCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).CreateFirmwareCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_CreateFirmwareCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).CreateFirmwareCampaign(ctx, req.(*CreateFirmwareCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_GetFirmwareCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FirmwareCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).GetFirmwareCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_GetFirmwareCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).GetFirmwareCampaign(ctx, req.(*FirmwareCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_PauseFirmwareCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FirmwareCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).PauseFirmwareCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_PauseFirmwareCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).PauseFirmwareCampaign(ctx, req.(*FirmwareCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_ResumeFirmwareCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeFirmwareCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ResumeFirmwareCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ResumeFirmwareCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ResumeFirmwareCampaign(ctx, req.(*ResumeFirmwareCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_GetDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).GetDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_GetDiagnostics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).GetDiagnostics(ctx, req.(*GetDiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_ListDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ListDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ListDiagnostics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ListDiagnostics(ctx, req.(*ListDiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_DownloadDiagnostics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadDiagnosticsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServiceServer).DownloadDiagnostics(m, &grpc.GenericServerStream[DownloadDiagnosticsRequest, DiagnosticsFileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlService_DownloadDiagnosticsServer = grpc.ServerStreamingServer[DiagnosticsFileChunk]
+
+func _ControlService_ReserveNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveNowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ReserveNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ReserveNow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ReserveNow(ctx, req.(*ReserveNowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).CancelReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_CancelReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).CancelReservation(ctx, req.(*CancelReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ListReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ListReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ListReservations(ctx, req.(*ListReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_SetIdTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIdTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).SetIdTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_SetIdTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).SetIdTag(ctx, req.(*SetIdTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_SyncLocalList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncLocalListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).SyncLocalList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_SyncLocalList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).SyncLocalList(ctx, req.(*SyncLocalListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_GetLocalListVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLocalListVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).GetLocalListVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_GetLocalListVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).GetLocalListVersion(ctx, req.(*GetLocalListVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_ClearCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ClearCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ClearCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ClearCache(ctx, req.(*ClearCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_SetAuthorizationCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAuthorizationCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).SetAuthorizationCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_SetAuthorizationCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).SetAuthorizationCache(ctx, req.(*SetAuthorizationCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_SetIdTagGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIdTagGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).SetIdTagGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_SetIdTagGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).SetIdTagGroup(ctx, req.(*SetIdTagGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_GetIdTagGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIdTagGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).GetIdTagGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_GetIdTagGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).GetIdTagGroup(ctx, req.(*GetIdTagGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ControlService_ServiceDesc is the grpc.ServiceDesc for ControlService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ControlService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "command.ControlService",
+	HandlerType: (*ControlServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Start",
+			Handler:    _ControlService_Start_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _ControlService_Stop_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _ControlService_Reset_Handler,
+		},
+		{
+			MethodName: "ChangeAvailability",
+			Handler:    _ControlService_ChangeAvailability_Handler,
+		},
+		{
+			MethodName: "GetConfiguration",
+			Handler:    _ControlService_GetConfiguration_Handler,
+		},
+		{
+			MethodName: "ChangeConfiguration",
+			Handler:    _ControlService_ChangeConfiguration_Handler,
+		},
+		{
+			MethodName: "GetConfigurationDrift",
+			Handler:    _ControlService_GetConfigurationDrift_Handler,
+		},
+		{
+			MethodName: "UnlockConnector",
+			Handler:    _ControlService_UnlockConnector_Handler,
+		},
+		{
+			MethodName: "TriggerMessage",
+			Handler:    _ControlService_TriggerMessage_Handler,
+		},
+		{
+			MethodName: "UpdateFirmware",
+			Handler:    _ControlService_UpdateFirmware_Handler,
+		},
+		{
+			MethodName: "CreateFirmwareCampaign",
+			Handler:    _ControlService_CreateFirmwareCampaign_Handler,
+		},
+		{
+			MethodName: "GetFirmwareCampaign",
+			Handler:    _ControlService_GetFirmwareCampaign_Handler,
+		},
+		{
+			MethodName: "PauseFirmwareCampaign",
+			Handler:    _ControlService_PauseFirmwareCampaign_Handler,
+		},
+		{
+			MethodName: "ResumeFirmwareCampaign",
+			Handler:    _ControlService_ResumeFirmwareCampaign_Handler,
+		},
+		{
+			MethodName: "GetDiagnostics",
+			Handler:    _ControlService_GetDiagnostics_Handler,
+		},
+		{
+			MethodName: "ListDiagnostics",
+			Handler:    _ControlService_ListDiagnostics_Handler,
+		},
+		{
+			MethodName: "ReserveNow",
+			Handler:    _ControlService_ReserveNow_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _ControlService_CancelReservation_Handler,
+		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _ControlService_ListReservations_Handler,
+		},
+		{
+			MethodName: "SetIdTag",
+			Handler:    _ControlService_SetIdTag_Handler,
+		},
+		{
+			MethodName: "SyncLocalList",
+			Handler:    _ControlService_SyncLocalList_Handler,
+		},
+		{
+			MethodName: "GetLocalListVersion",
+			Handler:    _ControlService_GetLocalListVersion_Handler,
+		},
+		{
+			MethodName: "ClearCache",
+			Handler:    _ControlService_ClearCache_Handler,
+		},
+		{
+			MethodName: "SetAuthorizationCache",
+			Handler:    _ControlService_SetAuthorizationCache_Handler,
+		},
+		{
+			MethodName: "SetIdTagGroup",
+			Handler:    _ControlService_SetIdTagGroup_Handler,
+		},
+		{
+			MethodName: "GetIdTagGroup",
+			Handler:    _ControlService_GetIdTagGroup_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadDiagnostics",
+			Handler:       _ControlService_DownloadDiagnostics_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/control/control.proto",
 }
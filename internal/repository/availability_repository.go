@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+type AvailabilityRepository struct {
+	db *sql.DB
+}
+
+func NewAvailabilityRepository(db *sql.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{db: db}
+}
+
+func (r *AvailabilityRepository) SetAvailability(a *models.Availability) error {
+	query := `INSERT INTO station_availability (station_id, connector_id, availability, updated_at) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE availability = VALUES(availability), updated_at = VALUES(updated_at)`
+	_, err := r.db.Exec(query, a.StationId, a.ConnectorId, a.Availability, a.UpdatedAt)
+	return err
+}
+
+// GetAvailabilityByStationID возвращает желаемую доступность станции, коннектор 0 идёт первым
+func (r *AvailabilityRepository) GetAvailabilityByStationID(stationId int) ([]*models.Availability, error) {
+	query := `SELECT station_id, connector_id, availability, updated_at FROM station_availability WHERE station_id = ? ORDER BY connector_id`
+	rows, err := r.db.Query(query, stationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*models.Availability
+	for rows.Next() {
+		var a models.Availability
+		if err := rows.Scan(&a.StationId, &a.ConnectorId, &a.Availability, &a.UpdatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &a)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+const upsertConfigurationKeyQuery = `INSERT INTO station_configuration (station_id, config_key, value, readonly, reboot_required, updated_at) VALUES (?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE value = VALUES(value), readonly = VALUES(readonly), reboot_required = VALUES(reboot_required), updated_at = VALUES(updated_at)`
+
+type ConfigurationRepository struct {
+	db *sql.DB
+}
+
+func NewConfigurationRepository(db *sql.DB) *ConfigurationRepository {
+	return &ConfigurationRepository{db: db}
+}
+
+func (r *ConfigurationRepository) GetConfigurationByStationID(stationId int) ([]*models.ConfigurationKey, error) {
+	query := `SELECT station_id, config_key, value, readonly, reboot_required, updated_at FROM station_configuration WHERE station_id = ? ORDER BY config_key`
+	rows, err := r.db.Query(query, stationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var keys []*models.ConfigurationKey
+	for rows.Next() {
+		var k models.ConfigurationKey
+		if err := rows.Scan(&k.StationId, &k.Key, &k.Value, &k.Readonly, &k.RebootRequired, &k.UpdatedAt); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &k)
+	}
+	return keys, rows.Err()
+}
+
+// UpsertConfigurationKeys обновляет значения отдельных ключей
+func (r *ConfigurationRepository) UpsertConfigurationKeys(keys []*models.ConfigurationKey) error {
+	for _, k := range keys {
+		if _, err := r.db.Exec(upsertConfigurationKeyQuery, k.StationId, k.Key, k.Value, k.Readonly, k.RebootRequired, k.UpdatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceConfiguration заменяет снимок конфигурации станции целиком (ответ GetConfiguration без ключей)
+func (r *ConfigurationRepository) ReplaceConfiguration(stationId int, keys []*models.ConfigurationKey) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`DELETE FROM station_configuration WHERE station_id = ?`, stationId); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if _, err := tx.Exec(upsertConfigurationKeyQuery, stationId, k.Key, k.Value, k.Readonly, k.RebootRequired, k.UpdatedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
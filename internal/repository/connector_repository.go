@@ -27,7 +27,7 @@ func (r *ConnectorRepository) Get(stationId int, id int) (*models.Connector, err
 }
 
 func (r *ConnectorRepository) GetByStationID(stationId int) ([]*models.Connector, error) {
-	query := "SELECT id, station_id, state FROM connectors WHERE station_id = ?"
+	query := "SELECT ocpp_id, station_id, state FROM connectors WHERE station_id = ?"
 	rows, err := r.db.Query(query, stationId)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+const (
+	selectDiagnosticsRequestFields = `id, station_id, location, start_time, stop_time, retries, retry_interval, status, file_name, created_at, updated_at`
+	selectDiagnosticsFileFields    = `id, station_id, request_id, name, size, created_at`
+)
+
+type DiagnosticsRepository struct {
+	db *sql.DB
+}
+
+func NewDiagnosticsRepository(db *sql.DB) *DiagnosticsRepository {
+	return &DiagnosticsRepository{db: db}
+}
+
+func (r *DiagnosticsRepository) CreateDiagnosticsRequest(d *models.DiagnosticsRequest) error {
+	query := `INSERT INTO diagnostics_requests (station_id, location, start_time, stop_time, retries, retry_interval, status, file_name, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.Exec(query, d.StationId, d.Location, nullString(d.StartTime), nullString(d.StopTime), d.Retries, d.RetryInterval, d.Status, d.FileName, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err == nil {
+		d.Id = int(id)
+	}
+	return err
+}
+
+func (r *DiagnosticsRepository) GetDiagnosticsRequest(id int) (*models.DiagnosticsRequest, error) {
+	query := `SELECT ` + selectDiagnosticsRequestFields + ` FROM diagnostics_requests WHERE id = ?`
+	d, err := scanDiagnosticsRequest(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return d, err
+}
+
+// GetActiveDiagnosticsRequest возвращает последний запрос станции, выгрузка по которому ещё не завершилась
+func (r *DiagnosticsRepository) GetActiveDiagnosticsRequest(stationId int) (*models.DiagnosticsRequest, error) {
+	query := `SELECT ` + selectDiagnosticsRequestFields + ` FROM diagnostics_requests WHERE station_id = ? AND status IN (?, ?) ORDER BY id DESC LIMIT 1`
+	d, err := scanDiagnosticsRequest(r.db.QueryRow(query, stationId, models.DiagnosticsRequested, models.DiagnosticsUploading))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return d, err
+}
+
+func (r *DiagnosticsRepository) GetDiagnosticsRequestsByStationID(stationId int) ([]*models.DiagnosticsRequest, error) {
+	query := `SELECT ` + selectDiagnosticsRequestFields + ` FROM diagnostics_requests WHERE station_id = ? ORDER BY id DESC`
+	rows, err := r.db.Query(query, stationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var requests []*models.DiagnosticsRequest
+	for rows.Next() {
+		d, err := scanDiagnosticsRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, d)
+	}
+	return requests, rows.Err()
+}
+
+func (r *DiagnosticsRepository) UpdateDiagnosticsRequest(d *models.DiagnosticsRequest) error {
+	query := `UPDATE diagnostics_requests SET status = ?, file_name = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, d.Status, d.FileName, d.UpdatedAt, d.Id)
+	return err
+}
+
+func (r *DiagnosticsRepository) CreateDiagnosticsFile(f *models.DiagnosticsFile) error {
+	query := `INSERT INTO diagnostics_files (station_id, request_id, name, size, created_at) VALUES (?, ?, ?, ?, ?)`
+	result, err := r.db.Exec(query, f.StationId, f.RequestId, f.Name, f.Size, f.CreatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err == nil {
+		f.Id = int(id)
+	}
+	return err
+}
+
+func (r *DiagnosticsRepository) GetDiagnosticsFile(id int) (*models.DiagnosticsFile, error) {
+	query := `SELECT ` + selectDiagnosticsFileFields + ` FROM diagnostics_files WHERE id = ?`
+	var f models.DiagnosticsFile
+	if err := r.db.QueryRow(query, id).Scan(&f.Id, &f.StationId, &f.RequestId, &f.Name, &f.Size, &f.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *DiagnosticsRepository) GetDiagnosticsFilesByStationID(stationId int) ([]*models.DiagnosticsFile, error) {
+	query := `SELECT ` + selectDiagnosticsFileFields + ` FROM diagnostics_files WHERE station_id = ? ORDER BY id DESC`
+	rows, err := r.db.Query(query, stationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*models.DiagnosticsFile
+	for rows.Next() {
+		var f models.DiagnosticsFile
+		if err := rows.Scan(&f.Id, &f.StationId, &f.RequestId, &f.Name, &f.Size, &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &f)
+	}
+	return result, rows.Err()
+}
+
+func scanDiagnosticsRequest(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.DiagnosticsRequest, error) {
+	var d models.DiagnosticsRequest
+	var startTime, stopTime sql.NullString
+	if err := row.Scan(&d.Id, &d.StationId, &d.Location, &startTime, &stopTime, &d.Retries, &d.RetryInterval, &d.Status, &d.FileName, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	d.StartTime = startTime.String
+	d.StopTime = stopTime.String
+	return &d, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+const (
+	selectFirmwareCampaignFields        = `id, firmware_url, target_version, retrieve_date, wave_size, current_wave, failure_threshold, resumed_failures, station_timeout, status, created_at, updated_at`
+	selectFirmwareCampaignStationFields = `campaign_id, station_id, wave, status, error, updated_at`
+)
+
+type FirmwareRepository struct {
+	db *sql.DB
+}
+
+func NewFirmwareRepository(db *sql.DB) *FirmwareRepository {
+	return &FirmwareRepository{db: db}
+}
+
+// CreateFirmwareCampaign создаёт кампанию вместе со списком станций
+func (r *FirmwareRepository) CreateFirmwareCampaign(c *models.FirmwareCampaign, stations []*models.FirmwareCampaignStation) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO firmware_campaigns (firmware_url, target_version, retrieve_date, wave_size, current_wave, failure_threshold, station_timeout, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := tx.Exec(query, c.FirmwareUrl, c.TargetVersion, nullString(c.RetrieveDate), c.WaveSize, c.CurrentWave, c.FailureThreshold, c.StationTimeout, c.Status, c.CreatedAt, c.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	c.Id = int(id)
+
+	for _, s := range stations {
+		s.CampaignId = c.Id
+		query := `INSERT INTO firmware_campaign_stations (campaign_id, station_id, wave, status, error, updated_at) VALUES (?, ?, ?, ?, ?, ?)`
+		if _, err := tx.Exec(query, s.CampaignId, s.StationId, s.Wave, s.Status, s.Error, s.UpdatedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *FirmwareRepository) GetFirmwareCampaign(id int) (*models.FirmwareCampaign, error) {
+	query := `SELECT ` + selectFirmwareCampaignFields + ` FROM firmware_campaigns WHERE id = ?`
+	c, err := scanFirmwareCampaign(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return c, err
+}
+
+func (r *FirmwareRepository) GetFirmwareCampaignsByStatus(status string) ([]*models.FirmwareCampaign, error) {
+	query := `SELECT ` + selectFirmwareCampaignFields + ` FROM firmware_campaigns WHERE status = ? ORDER BY id`
+	rows, err := r.db.Query(query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var campaigns []*models.FirmwareCampaign
+	for rows.Next() {
+		c, err := scanFirmwareCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, c)
+	}
+	return campaigns, rows.Err()
+}
+
+func (r *FirmwareRepository) UpdateFirmwareCampaign(c *models.FirmwareCampaign) error {
+	query := `UPDATE firmware_campaigns SET current_wave = ?, failure_threshold = ?, resumed_failures = ?, status = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, c.CurrentWave, c.FailureThreshold, c.ResumedFailures, c.Status, c.UpdatedAt, c.Id)
+	return err
+}
+
+func (r *FirmwareRepository) GetFirmwareCampaignStations(campaignId int) ([]*models.FirmwareCampaignStation, error) {
+	query := `SELECT ` + selectFirmwareCampaignStationFields + ` FROM firmware_campaign_stations WHERE campaign_id = ? ORDER BY wave, station_id`
+	rows, err := r.db.Query(query, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var stations []*models.FirmwareCampaignStation
+	for rows.Next() {
+		var s models.FirmwareCampaignStation
+		if err := rows.Scan(&s.CampaignId, &s.StationId, &s.Wave, &s.Status, &s.Error, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		stations = append(stations, &s)
+	}
+	return stations, rows.Err()
+}
+
+// GetActiveFirmwareCampaignStation возвращает незавершённое участие станции в кампании,
+// которой уже отправлен UpdateFirmware
+func (r *FirmwareRepository) GetActiveFirmwareCampaignStation(stationId int) (*models.FirmwareCampaignStation, error) {
+	query := `SELECT ` + selectFirmwareCampaignStationFields + ` FROM firmware_campaign_stations
+		WHERE station_id = ? AND status NOT IN (?, ?, ?) ORDER BY campaign_id DESC LIMIT 1`
+	row := r.db.QueryRow(query, stationId, models.FirmwareStationPending, models.FirmwareStationVerified, models.FirmwareStationFailed)
+	var s models.FirmwareCampaignStation
+	if err := row.Scan(&s.CampaignId, &s.StationId, &s.Wave, &s.Status, &s.Error, &s.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *FirmwareRepository) UpdateFirmwareCampaignStation(s *models.FirmwareCampaignStation) error {
+	query := `UPDATE firmware_campaign_stations SET status = ?, error = ?, updated_at = ? WHERE campaign_id = ? AND station_id = ?`
+	_, err := r.db.Exec(query, s.Status, s.Error, s.UpdatedAt, s.CampaignId, s.StationId)
+	return err
+}
+
+func scanFirmwareCampaign(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.FirmwareCampaign, error) {
+	var c models.FirmwareCampaign
+	var retrieveDate sql.NullString
+	if err := row.Scan(&c.Id, &c.FirmwareUrl, &c.TargetVersion, &retrieveDate, &c.WaveSize, &c.CurrentWave, &c.FailureThreshold, &c.ResumedFailures, &c.StationTimeout, &c.Status, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	c.RetrieveDate = retrieveDate.String
+	return &c, nil
+}
+
+// nullString сохраняет пустую строку как NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
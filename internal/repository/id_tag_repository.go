@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+const selectIdTagFields = `id_tag, user_id, parent_id_tag, status, expiry_date, version, updated_at`
+
+type IdTagRepository struct {
+	db *sql.DB
+}
+
+func NewIdTagRepository(db *sql.DB) *IdTagRepository {
+	return &IdTagRepository{db: db}
+}
+
+func (r *IdTagRepository) GetIdTag(idTag string) (*models.IdTag, error) {
+	query := `SELECT ` + selectIdTagFields + ` FROM id_tags WHERE id_tag = ?`
+	t, err := scanIdTag(r.db.QueryRow(query, idTag))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return t, err
+}
+
+// SaveIdTag создаёт или обновляет idTag и присваивает ему следующую версию локального списка.
+// Карты группы, если t - их parentIdTag, получают ту же версию: станции должны получить их заново
+func (r *IdTagRepository) SaveIdTag(t *models.IdTag) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var version int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM id_tags FOR UPDATE`).Scan(&version); err != nil {
+		return err
+	}
+	t.Version = version + 1
+
+	query := `INSERT INTO id_tags (id_tag, user_id, parent_id_tag, status, expiry_date, version, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE user_id = VALUES(user_id), parent_id_tag = VALUES(parent_id_tag), status = VALUES(status),
+		expiry_date = VALUES(expiry_date), version = VALUES(version), updated_at = VALUES(updated_at)`
+	if _, err := tx.Exec(query, t.IdTag, t.UserId, t.ParentIdTag, t.Status, nullString(t.ExpiryDate), t.Version, t.UpdatedAt); err != nil {
+		return err
+	}
+	if err := bumpGroupMembers(tx, t.IdTag, t.Version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// bumpGroupMembers присваивает картам группы parentIdTag версию локального списка version
+func bumpGroupMembers(tx *sql.Tx, parentIdTag string, version int) error {
+	_, err := tx.Exec(`UPDATE id_tags SET version = ? WHERE parent_id_tag = ?`, version, parentIdTag)
+	return err
+}
+
+// GetValidIdTags возвращает принятые idTag, срок действия которых не истёк к now
+func (r *IdTagRepository) GetValidIdTags(now string) ([]*models.IdTag, error) {
+	query := `SELECT ` + selectIdTagFields + ` FROM id_tags WHERE status = ? AND (expiry_date IS NULL OR expiry_date > ?) ORDER BY id_tag`
+	return r.queryIdTags(query, models.IdTagAccepted, now)
+}
+
+// GetIdTagsChangedSince возвращает idTag, изменённые после версии списка version
+func (r *IdTagRepository) GetIdTagsChangedSince(version int) ([]*models.IdTag, error) {
+	query := `SELECT ` + selectIdTagFields + ` FROM id_tags WHERE version > ? ORDER BY version`
+	return r.queryIdTags(query, version)
+}
+
+// GetIdTagListVersion возвращает текущую версию локального списка, 0 - idTag ещё нет
+func (r *IdTagRepository) GetIdTagListVersion() (int, error) {
+	var version int
+	err := r.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM id_tags`).Scan(&version)
+	return version, err
+}
+
+// GetLocalListVersion возвращает версию списка, последней отправленную станции, 0 - список не отправлялся
+func (r *IdTagRepository) GetLocalListVersion(stationId int) (int, error) {
+	var version int
+	err := r.db.QueryRow(`SELECT version FROM station_local_list WHERE station_id = ?`, stationId).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (r *IdTagRepository) SetLocalListVersion(stationId int, version int, updatedAt string) error {
+	query := `INSERT INTO station_local_list (station_id, version, updated_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE version = VALUES(version), updated_at = VALUES(updated_at)`
+	_, err := r.db.Exec(query, stationId, version, updatedAt)
+	return err
+}
+
+func (r *IdTagRepository) GetIdTagAccess(idTag string) ([]*models.IdTagAccess, error) {
+	return r.queryIdTagAccess(`SELECT id_tag, station_id, location_id FROM id_tag_access WHERE id_tag = ?`, idTag)
+}
+
+// idTagAccessBatch - сколько idTag передаётся в одном IN (...)
+const idTagAccessBatch = 500
+
+// GetIdTagsAccess возвращает ограничения перечисленных idTag, нужен для сборки локального списка станции
+func (r *IdTagRepository) GetIdTagsAccess(idTags []string) ([]*models.IdTagAccess, error) {
+	var result []*models.IdTagAccess
+	for start := 0; start < len(idTags); start += idTagAccessBatch {
+		end := start + idTagAccessBatch
+		if end > len(idTags) {
+			end = len(idTags)
+		}
+		batch := idTags[start:end]
+		args := make([]interface{}, len(batch))
+		for i, idTag := range batch {
+			args[i] = idTag
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(batch)), ", ")
+		access, err := r.queryIdTagAccess(`SELECT id_tag, station_id, location_id FROM id_tag_access WHERE id_tag IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, access...)
+	}
+	return result, nil
+}
+
+// ReplaceIdTagAccess заменяет ограничения idTag, пустой access снимает их
+func (r *IdTagRepository) ReplaceIdTagAccess(idTag string, access []*models.IdTagAccess) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM id_tag_access WHERE id_tag = ?`, idTag); err != nil {
+		return err
+	}
+	for _, a := range access {
+		if _, err := tx.Exec(`INSERT INTO id_tag_access (id_tag, station_id, location_id) VALUES (?, ?, ?)`, idTag, a.StationId, a.LocationId); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *IdTagRepository) GetIdTagGroup(parentIdTag string) (*models.IdTagGroup, error) {
+	query := `SELECT parent_id_tag, name, max_concurrent_sessions, monthly_energy_kwh, updated_at FROM id_tag_groups WHERE parent_id_tag = ?`
+	var g models.IdTagGroup
+	err := r.db.QueryRow(query, parentIdTag).Scan(&g.ParentIdTag, &g.Name, &g.MaxConcurrentSessions, &g.MonthlyEnergyKwh, &g.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// SaveIdTagGroup создаёт или обновляет группу и присваивает её картам следующую версию локального списка
+func (r *IdTagRepository) SaveIdTagGroup(g *models.IdTagGroup) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var version int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM id_tags FOR UPDATE`).Scan(&version); err != nil {
+		return err
+	}
+	query := `INSERT INTO id_tag_groups (parent_id_tag, name, max_concurrent_sessions, monthly_energy_kwh, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE name = VALUES(name), max_concurrent_sessions = VALUES(max_concurrent_sessions),
+		monthly_energy_kwh = VALUES(monthly_energy_kwh), updated_at = VALUES(updated_at)`
+	if _, err := tx.Exec(query, g.ParentIdTag, g.Name, g.MaxConcurrentSessions, g.MonthlyEnergyKwh, g.UpdatedAt); err != nil {
+		return err
+	}
+	if err := bumpGroupMembers(tx, g.ParentIdTag, version+1); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *IdTagRepository) queryIdTagAccess(query string, args ...interface{}) ([]*models.IdTagAccess, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*models.IdTagAccess
+	for rows.Next() {
+		var a models.IdTagAccess
+		if err := rows.Scan(&a.IdTag, &a.StationId, &a.LocationId); err != nil {
+			return nil, err
+		}
+		result = append(result, &a)
+	}
+	return result, rows.Err()
+}
+
+func (r *IdTagRepository) queryIdTags(query string, args ...interface{}) ([]*models.IdTag, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*models.IdTag
+	for rows.Next() {
+		t, err := scanIdTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+func scanIdTag(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.IdTag, error) {
+	var t models.IdTag
+	var expiryDate sql.NullString
+	if err := row.Scan(&t.IdTag, &t.UserId, &t.ParentIdTag, &t.Status, &expiryDate, &t.Version, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	t.ExpiryDate = expiryDate.String
+	return &t, nil
+}
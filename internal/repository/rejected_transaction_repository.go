@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+const selectRejectedTransactionFields = `id, station_id, connector_id, id_tag, status, meter_start, meter_stop, started_at, stopped_at, created_at`
+
+type RejectedTransactionRepository struct {
+	db *sql.DB
+}
+
+func NewRejectedTransactionRepository(db *sql.DB) *RejectedTransactionRepository {
+	return &RejectedTransactionRepository{db: db}
+}
+
+func (r *RejectedTransactionRepository) CreateRejectedTransaction(t *models.RejectedTransaction) error {
+	query := `INSERT INTO rejected_transactions (station_id, connector_id, id_tag, status, meter_start, started_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.Exec(query, t.StationId, t.ConnectorId, t.IdTag, t.Status, t.MeterStart, nullString(t.StartedAt), t.CreatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err == nil {
+		t.Id = int(id)
+	}
+	return err
+}
+
+func (r *RejectedTransactionRepository) GetRejectedTransaction(id int) (*models.RejectedTransaction, error) {
+	query := `SELECT ` + selectRejectedTransactionFields + ` FROM rejected_transactions WHERE id = ?`
+	var t models.RejectedTransaction
+	var meterStop sql.NullInt64
+	var startedAt, stoppedAt sql.NullString
+	err := r.db.QueryRow(query, id).Scan(&t.Id, &t.StationId, &t.ConnectorId, &t.IdTag, &t.Status, &t.MeterStart, &meterStop, &startedAt, &stoppedAt, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.StartedAt = startedAt.String
+	t.StoppedAt = stoppedAt.String
+	if meterStop.Valid {
+		v := int(meterStop.Int64)
+		t.MeterStop = &v
+	}
+	return &t, nil
+}
+
+func (r *RejectedTransactionRepository) UpdateRejectedTransaction(t *models.RejectedTransaction) error {
+	query := `UPDATE rejected_transactions SET meter_stop = ?, stopped_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, t.MeterStop, nullString(t.StoppedAt), t.Id)
+	return err
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/delevopersmoke/ocpp_microservice/internal/models"
 )
@@ -10,13 +11,27 @@ type Repository struct {
 	Connector
 	Station
 	Session
+	Availability
+	Configuration
+	Firmware
+	Diagnostics
+	Reservation
+	IdTag
+	RejectedTransaction
 }
 
 func NewRepository(db *sql.DB) *Repository {
 	return &Repository{
-		Station:   NewStationRepository(db),
-		Connector: NewConnectorRepository(db),
-		Session:   NewSessionRepository(db),
+		Station:             NewStationRepository(db),
+		Connector:           NewConnectorRepository(db),
+		Session:             NewSessionRepository(db),
+		Availability:        NewAvailabilityRepository(db),
+		Configuration:       NewConfigurationRepository(db),
+		Firmware:            NewFirmwareRepository(db),
+		Diagnostics:         NewDiagnosticsRepository(db),
+		Reservation:         NewReservationRepository(db),
+		IdTag:               NewIdTagRepository(db),
+		RejectedTransaction: NewRejectedTransactionRepository(db),
 	}
 }
 
@@ -28,6 +43,12 @@ type Station interface {
 	Delete(id int) error
 	GetByChargeBoxId(chargeBoxId string) (*models.Station, error)
 	SetAllOffline() error
+	SetOnline(id int, ocppVersion string, connectedAt time.Time) error
+	SetOffline(id int, disconnectedAt time.Time) error
+	GetLocationID(id int) (int, error)
+	SetAllCacheClearPending() error
+	GetCacheClearPending(id int) (bool, error)
+	SetCacheClearPending(id int, pending bool) error
 }
 
 type Connector interface {
@@ -45,4 +66,71 @@ type Session interface {
 	GetFinishedSessionByID(id int) (*models.Session, error)
 	UpdateFinishedSession(s *models.Session) error
 	GetCurrentSessionByConnector(stationId int, connectorOcppId int) (*models.Session, error)
+	GetCurrentSessionByTransactionId(stationId int, transactionId string) (*models.Session, error)
+	CreateCurrentSession(s *models.Session) error
+	GetSessionTemplate(stationId int, connectorOcppId int) (*models.Session, error)
+	CountGroupActiveSessions(parentIdTag string) (int, error)
+	GetGroupEnergy(parentIdTag string, since string) (float64, error)
+}
+
+type Availability interface {
+	SetAvailability(a *models.Availability) error
+	GetAvailabilityByStationID(stationId int) ([]*models.Availability, error)
+}
+
+type Configuration interface {
+	GetConfigurationByStationID(stationId int) ([]*models.ConfigurationKey, error)
+	UpsertConfigurationKeys(keys []*models.ConfigurationKey) error
+	ReplaceConfiguration(stationId int, keys []*models.ConfigurationKey) error
+}
+
+type Firmware interface {
+	CreateFirmwareCampaign(c *models.FirmwareCampaign, stations []*models.FirmwareCampaignStation) error
+	GetFirmwareCampaign(id int) (*models.FirmwareCampaign, error)
+	GetFirmwareCampaignsByStatus(status string) ([]*models.FirmwareCampaign, error)
+	UpdateFirmwareCampaign(c *models.FirmwareCampaign) error
+	GetFirmwareCampaignStations(campaignId int) ([]*models.FirmwareCampaignStation, error)
+	GetActiveFirmwareCampaignStation(stationId int) (*models.FirmwareCampaignStation, error)
+	UpdateFirmwareCampaignStation(s *models.FirmwareCampaignStation) error
+}
+
+type Diagnostics interface {
+	CreateDiagnosticsRequest(d *models.DiagnosticsRequest) error
+	GetDiagnosticsRequest(id int) (*models.DiagnosticsRequest, error)
+	GetActiveDiagnosticsRequest(stationId int) (*models.DiagnosticsRequest, error)
+	GetDiagnosticsRequestsByStationID(stationId int) ([]*models.DiagnosticsRequest, error)
+	UpdateDiagnosticsRequest(d *models.DiagnosticsRequest) error
+	CreateDiagnosticsFile(f *models.DiagnosticsFile) error
+	GetDiagnosticsFile(id int) (*models.DiagnosticsFile, error)
+	GetDiagnosticsFilesByStationID(stationId int) ([]*models.DiagnosticsFile, error)
+}
+
+type Reservation interface {
+	CreateReservation(r *models.Reservation) error
+	GetReservation(id int) (*models.Reservation, error)
+	GetActiveReservationByConnector(stationId int, connectorId int) (*models.Reservation, error)
+	GetReservationsByStationID(stationId int) ([]*models.Reservation, error)
+	GetExpiredReservations(now string) ([]*models.Reservation, error)
+	UpdateReservation(r *models.Reservation) error
+}
+
+type RejectedTransaction interface {
+	CreateRejectedTransaction(t *models.RejectedTransaction) error
+	GetRejectedTransaction(id int) (*models.RejectedTransaction, error)
+	UpdateRejectedTransaction(t *models.RejectedTransaction) error
+}
+
+type IdTag interface {
+	GetIdTag(idTag string) (*models.IdTag, error)
+	SaveIdTag(t *models.IdTag) error
+	GetValidIdTags(now string) ([]*models.IdTag, error)
+	GetIdTagsChangedSince(version int) ([]*models.IdTag, error)
+	GetIdTagListVersion() (int, error)
+	GetLocalListVersion(stationId int) (int, error)
+	SetLocalListVersion(stationId int, version int, updatedAt string) error
+	GetIdTagAccess(idTag string) ([]*models.IdTagAccess, error)
+	GetIdTagsAccess(idTags []string) ([]*models.IdTagAccess, error)
+	ReplaceIdTagAccess(idTag string, access []*models.IdTagAccess) error
+	GetIdTagGroup(parentIdTag string) (*models.IdTagGroup, error)
+	SaveIdTagGroup(g *models.IdTagGroup) error
 }
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+const selectReservationFields = `id, station_id, connector_id, id_tag, parent_id_tag, expiry_date, status, session_id, created_at, updated_at`
+
+type ReservationRepository struct {
+	db *sql.DB
+}
+
+func NewReservationRepository(db *sql.DB) *ReservationRepository {
+	return &ReservationRepository{db: db}
+}
+
+func (r *ReservationRepository) CreateReservation(res *models.Reservation) error {
+	query := `INSERT INTO reservations (station_id, connector_id, id_tag, parent_id_tag, expiry_date, status, session_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.Exec(query, res.StationId, res.ConnectorId, res.IdTag, res.ParentIdTag, res.ExpiryDate, res.Status, res.SessionId, res.CreatedAt, res.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err == nil {
+		res.Id = int(id)
+	}
+	return err
+}
+
+func (r *ReservationRepository) GetReservation(id int) (*models.Reservation, error) {
+	query := `SELECT ` + selectReservationFields + ` FROM reservations WHERE id = ?`
+	res, err := scanReservation(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return res, err
+}
+
+// GetActiveReservationByConnector возвращает действующее резервирование коннектора,
+// connectorId 0 - резервирование любого коннектора станции
+func (r *ReservationRepository) GetActiveReservationByConnector(stationId int, connectorId int) (*models.Reservation, error) {
+	query := `SELECT ` + selectReservationFields + ` FROM reservations WHERE station_id = ? AND connector_id = ? AND status = ? ORDER BY id DESC LIMIT 1`
+	res, err := scanReservation(r.db.QueryRow(query, stationId, connectorId, models.ReservationActive))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return res, err
+}
+
+func (r *ReservationRepository) GetReservationsByStationID(stationId int) ([]*models.Reservation, error) {
+	query := `SELECT ` + selectReservationFields + ` FROM reservations WHERE station_id = ? ORDER BY id DESC`
+	return r.queryReservations(query, stationId)
+}
+
+// GetExpiredReservations возвращает действующие резервирования с expiry_date не позже now
+func (r *ReservationRepository) GetExpiredReservations(now string) ([]*models.Reservation, error) {
+	query := `SELECT ` + selectReservationFields + ` FROM reservations WHERE status = ? AND expiry_date <= ?`
+	return r.queryReservations(query, models.ReservationActive, now)
+}
+
+func (r *ReservationRepository) UpdateReservation(res *models.Reservation) error {
+	query := `UPDATE reservations SET status = ?, session_id = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, res.Status, res.SessionId, res.UpdatedAt, res.Id)
+	return err
+}
+
+func (r *ReservationRepository) queryReservations(query string, args ...interface{}) ([]*models.Reservation, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*models.Reservation
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+	return result, rows.Err()
+}
+
+func scanReservation(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.Reservation, error) {
+	var res models.Reservation
+	if err := row.Scan(&res.Id, &res.StationId, &res.ConnectorId, &res.IdTag, &res.ParentIdTag, &res.ExpiryDate, &res.Status, &res.SessionId, &res.CreatedAt, &res.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -47,12 +47,16 @@ const (
 		location_photo_url,
 		owner,
 		time_left,
-		total_price
+		total_price,
+		transaction_id,
+		parent_id_tag,
+		meter_start
 	`
 
-	getCurrentSessionByIDQuery        = "SELECT " + selectCurrentSessionFields + " FROM " + currentSessionsTable + " WHERE id = ?"
-	getCurrentSessionByIdTagQuery     = "SELECT " + selectCurrentSessionFields + " FROM " + currentSessionsTable + " WHERE id_tag = ?"
-	getCurrentSessionByConnectorQuery = "SELECT " + selectCurrentSessionFields + " FROM " + currentSessionsTable + " WHERE station_id = ? AND connector_ocpp_id = ?"
+	getCurrentSessionByIDQuery            = "SELECT " + selectCurrentSessionFields + " FROM " + currentSessionsTable + " WHERE id = ?"
+	getCurrentSessionByIdTagQuery         = "SELECT " + selectCurrentSessionFields + " FROM " + currentSessionsTable + " WHERE id_tag = ?"
+	getCurrentSessionByConnectorQuery     = "SELECT " + selectCurrentSessionFields + " FROM " + currentSessionsTable + " WHERE station_id = ? AND connector_ocpp_id = ?"
+	getCurrentSessionByTransactionIdQuery = "SELECT " + selectCurrentSessionFields + " FROM " + currentSessionsTable + " WHERE station_id = ? AND transaction_id = ?"
 
 	updateCurrentSessionQuery = `
 		UPDATE ` + currentSessionsTable + ` SET
@@ -75,9 +79,68 @@ const (
 		was_start_transaction=?,
 		was_stop_transaction=?,
 		time_left=?,
-		total_price=?
+		total_price=?,
+		transaction_id=?,
+		meter_start=?
 		WHERE id=?`
 
+	insertCurrentSessionQuery = `
+		INSERT INTO ` + currentSessionsTable + ` (
+			station_id,
+			location_id,
+			user_id,
+			email,
+			id_tag,
+			connector_id,
+			connector_ocpp_id,
+			connector_type,
+			connector_power,
+			begin,
+			end,
+			price_limit,
+			price_per_kwh,
+			percent_limit,
+			was_start_accepted,
+			was_start_transaction,
+			location_country,
+			location_city,
+			location_street,
+			station_serial,
+			location_photo_url,
+			owner,
+			transaction_id,
+			parent_id_tag
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	// getSessionTemplateQuery берёт описание коннектора, локации и тариф из последней сессии на коннекторе:
+	// текущей, подготовленной приложением, или завершённой. id текущей и завершённой сессии совпадают
+	getSessionTemplateQuery = `
+		SELECT location_id, connector_id, connector_type, connector_power, price_per_kwh,
+			location_country, location_city, location_street, station_serial, location_photo_url, owner
+		FROM (
+			SELECT id, location_id, connector_id, connector_type, connector_power, price_per_kwh,
+				location_country, location_city, location_street, station_serial, location_photo_url, owner
+			FROM ` + currentSessionsTable + ` WHERE station_id = ? AND connector_ocpp_id = ?
+			UNION ALL
+			SELECT f.id, f.location_id, f.connector_id, f.connector_type, f.connector_power, f.price_per_kwh,
+				f.location_country, f.location_city, f.location_street, f.station_serial, f.location_photo_url, f.owner
+			FROM ` + finishedSessionsTable + ` f JOIN connectors c ON c.id = f.connector_id
+			WHERE c.station_id = ? AND c.ocpp_id = ?
+		) s
+		ORDER BY id DESC LIMIT 1`
+
+	countGroupActiveSessionsQuery = "SELECT COUNT(*) FROM " + currentSessionsTable + " WHERE parent_id_tag = ? AND was_start_transaction = 1 AND was_stop_transaction = 0"
+
+	// getGroupEnergyQuery складывает энергию завершённых с начала периода и идущих сессий группы.
+	// Идущая сессия учитывается, когда известно показание в начале транзакции: до этого
+	// charged_energy может хранить показание счётчика, а не энергию сессии в кВт·ч
+	getGroupEnergyQuery = `
+		SELECT COALESCE(SUM(charged_energy), 0) FROM (
+			SELECT charged_energy FROM ` + finishedSessionsTable + ` WHERE parent_id_tag = ? AND begin >= ?
+			UNION ALL
+			SELECT charged_energy FROM ` + currentSessionsTable + ` WHERE parent_id_tag = ? AND was_start_transaction = 1 AND meter_start IS NOT NULL
+		) e`
+
 	deleteCurrentSessionQuery = "DELETE FROM " + currentSessionsTable + " WHERE id = ?"
 
 	insertFinishedSessionQuery = `
@@ -115,8 +178,9 @@ const (
 			total_price,
 			time_left,
 			location_photo_url,
-			owner
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+			owner,
+			parent_id_tag
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	getFinishedSessionByIDQuery = `
 		SELECT
@@ -229,15 +293,75 @@ func (r *SessionRepository) GetCurrentSessionByConnector(stationId int, connecto
 	return &s, nil
 }
 
+// GetCurrentSessionByTransactionId retrieves a current session by the OCPP 2.0.1 transactionId issued by the station
+func (r *SessionRepository) GetCurrentSessionByTransactionId(stationId int, transactionId string) (*models.Session, error) {
+	row := r.db.QueryRow(getCurrentSessionByTransactionIdQuery, stationId, transactionId)
+	var s models.Session
+	err := scanSession(row, &s)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
 // UpdateCurrentSession updates an existing current session
 func (r *SessionRepository) UpdateCurrentSession(s *models.Session) error {
 	_, err := r.db.Exec(updateCurrentSessionQuery,
 		s.IdTag, s.Begin, s.End, s.Voltage, s.Current, s.Power, s.SOC, s.SOCBegin, s.SOCEnd, s.MaxPower, s.ChargedEnergy, s.PriceLimit, s.PricePerKwH, s.PercentLimit,
-		s.WasStartAccepted, s.WasFirstMeterValues, s.WasStartTransaction, s.WasStopTransaction, s.TimeLeft, s.TotalPrice, s.Id,
+		s.WasStartAccepted, s.WasFirstMeterValues, s.WasStartTransaction, s.WasStopTransaction, s.TimeLeft, s.TotalPrice, s.TransactionId, s.MeterStart, s.Id,
 	)
 	return err
 }
 
+// CreateCurrentSession creates a current session and sets its ID
+func (r *SessionRepository) CreateCurrentSession(s *models.Session) error {
+	result, err := r.db.Exec(insertCurrentSessionQuery,
+		s.StationId, s.LocationId, s.UserId, s.Email, s.IdTag, s.ConnectorId, s.ConnectorOcppId, s.ConnectorType, s.ConnectorPower, s.Begin, s.End,
+		s.PriceLimit, s.PricePerKwH, s.PercentLimit, s.WasStartAccepted, s.WasStartTransaction,
+		s.LocationCountry, s.LocationCity, s.LocationStreet, s.StationSerial, s.LocationPhotoUrl, s.Owner, s.TransactionId, s.ParentIdTag,
+	)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err == nil {
+		s.Id = int(id)
+	}
+	return err
+}
+
+// GetSessionTemplate returns connector, location and tariff fields of the last session on the connector
+func (r *SessionRepository) GetSessionTemplate(stationId int, connectorOcppId int) (*models.Session, error) {
+	row := r.db.QueryRow(getSessionTemplateQuery, stationId, connectorOcppId, stationId, connectorOcppId)
+	s := models.Session{StationId: stationId, ConnectorOcppId: connectorOcppId}
+	err := row.Scan(&s.LocationId, &s.ConnectorId, &s.ConnectorType, &s.ConnectorPower, &s.PricePerKwH,
+		&s.LocationCountry, &s.LocationCity, &s.LocationStreet, &s.StationSerial, &s.LocationPhotoUrl, &s.Owner)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// CountGroupActiveSessions returns the number of running transactions of the parentIdTag group
+func (r *SessionRepository) CountGroupActiveSessions(parentIdTag string) (int, error) {
+	var count int
+	err := r.db.QueryRow(countGroupActiveSessionsQuery, parentIdTag).Scan(&count)
+	return count, err
+}
+
+// GetGroupEnergy returns energy in kWh charged by the parentIdTag group since the given time, running sessions included
+func (r *SessionRepository) GetGroupEnergy(parentIdTag string, since string) (float64, error) {
+	var energy float64
+	err := r.db.QueryRow(getGroupEnergyQuery, parentIdTag, since, parentIdTag).Scan(&energy)
+	return energy, err
+}
+
 // DeleteCurrentSession deletes a current session by its ID
 func (r *SessionRepository) DeleteCurrentSession(id int) error {
 	_, err := r.db.Exec(deleteCurrentSessionQuery, id)
@@ -249,7 +373,7 @@ func (r *SessionRepository) CreateFinishedSession(s *models.Session) error {
 	_, err := r.db.Exec(insertFinishedSessionQuery,
 		s.Id, s.StationId, s.LocationId, s.UserId, s.Email, s.IdTag, s.ConnectorId, s.ConnectorType, s.ConnectorPower, s.Begin, s.End, s.Voltage, s.Current, s.Power, s.SOC, s.SOCBegin, s.SOCEnd, s.MaxPower,
 		s.ChargedEnergy, s.PriceLimit, s.PricePerKwH, s.PercentLimit, s.WasStartAccepted, s.WasFirstMeterValues, s.WasStartTransaction, s.WasStopTransaction,
-		s.LocationCountry, s.LocationCity, s.LocationStreet, s.StationSerial, s.TotalPrice, s.TimeLeft, s.LocationPhotoUrl, s.Owner,
+		s.LocationCountry, s.LocationCity, s.LocationStreet, s.StationSerial, s.TotalPrice, s.TimeLeft, s.LocationPhotoUrl, s.Owner, s.ParentIdTag,
 	)
 	return err
 }
@@ -281,6 +405,6 @@ func scanSession(scanner interface {
 	Scan(dest ...interface{}) error
 }, s *models.Session) error {
 	return scanner.Scan(
-		&s.Id, &s.StationId, &s.LocationId, &s.UserId, &s.Email, &s.IdTag, &s.ConnectorId, &s.ConnectorOcppId, &s.ConnectorType, &s.ConnectorPower, &s.Begin, &s.End, &s.Voltage, &s.Current, &s.Power, &s.SOC, &s.SOCBegin, &s.SOCEnd, &s.MaxPower, &s.ChargedEnergy, &s.PriceLimit, &s.PricePerKwH, &s.PercentLimit, &s.WasStartAccepted, &s.WasFirstMeterValues, &s.WasStartTransaction, &s.WasStopTransaction, &s.LocationCountry, &s.LocationCity, &s.LocationStreet, &s.StationSerial, &s.LocationPhotoUrl, &s.Owner, &s.TimeLeft, &s.TotalPrice,
+		&s.Id, &s.StationId, &s.LocationId, &s.UserId, &s.Email, &s.IdTag, &s.ConnectorId, &s.ConnectorOcppId, &s.ConnectorType, &s.ConnectorPower, &s.Begin, &s.End, &s.Voltage, &s.Current, &s.Power, &s.SOC, &s.SOCBegin, &s.SOCEnd, &s.MaxPower, &s.ChargedEnergy, &s.PriceLimit, &s.PricePerKwH, &s.PercentLimit, &s.WasStartAccepted, &s.WasFirstMeterValues, &s.WasStartTransaction, &s.WasStopTransaction, &s.LocationCountry, &s.LocationCity, &s.LocationStreet, &s.StationSerial, &s.LocationPhotoUrl, &s.Owner, &s.TimeLeft, &s.TotalPrice, &s.TransactionId, &s.ParentIdTag, &s.MeterStart,
 	)
 }
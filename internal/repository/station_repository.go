@@ -2,6 +2,8 @@ package repository
 
 import (
 	"database/sql"
+	"time"
+
 	"github.com/delevopersmoke/ocpp_microservice/internal/models"
 )
 
@@ -14,8 +16,8 @@ func NewStationRepository(db *sql.DB) *StationRepository {
 }
 
 func (r *StationRepository) Create(station *models.Station) error {
-	query := `INSERT INTO stations (charge_box_id, charge_box_serial, charge_box_vendor, charge_box_model, charge_box_firmware, state) VALUES (?, ?, ?, ?, ?, ?)`
-	result, err := r.db.Exec(query, station.ChargeBoxId, station.ChargeBoxSerial, station.ChargeBoxVendor, station.ChargeBoxModel, station.ChargeBoxFirmware, station.State)
+	query := `INSERT INTO stations (charge_box_id, charge_box_serial, charge_box_vendor, charge_box_model, charge_box_firmware, state, ocpp_version) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.Exec(query, station.ChargeBoxId, station.ChargeBoxSerial, station.ChargeBoxVendor, station.ChargeBoxModel, station.ChargeBoxFirmware, station.State, station.OcppVersion)
 	if err != nil {
 		return err
 	}
@@ -27,10 +29,10 @@ func (r *StationRepository) Create(station *models.Station) error {
 }
 
 func (r *StationRepository) GetByID(id int) (*models.Station, error) {
-	query := `SELECT id, charge_box_id, charge_box_serial, charge_box_vendor, charge_box_model, charge_box_firmware, state FROM stations WHERE id = ?`
+	query := `SELECT id, charge_box_id, charge_box_serial, charge_box_vendor, charge_box_model, charge_box_firmware, state, ocpp_version FROM stations WHERE id = ?`
 	row := r.db.QueryRow(query, id)
 	var s models.Station
-	if err := row.Scan(&s.Id, &s.ChargeBoxId, &s.ChargeBoxSerial, &s.ChargeBoxVendor, &s.ChargeBoxModel, &s.ChargeBoxFirmware, &s.State); err != nil {
+	if err := row.Scan(&s.Id, &s.ChargeBoxId, &s.ChargeBoxSerial, &s.ChargeBoxVendor, &s.ChargeBoxModel, &s.ChargeBoxFirmware, &s.State, &s.OcppVersion); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -40,7 +42,7 @@ func (r *StationRepository) GetByID(id int) (*models.Station, error) {
 }
 
 func (r *StationRepository) GetAll() ([]*models.Station, error) {
-	query := `SELECT id, charge_box_id, charge_box_serial, charge_box_vendor, charge_box_model, charge_box_firmware, state FROM stations`
+	query := `SELECT id, charge_box_id, charge_box_serial, charge_box_vendor, charge_box_model, charge_box_firmware, state, ocpp_version FROM stations`
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -49,7 +51,7 @@ func (r *StationRepository) GetAll() ([]*models.Station, error) {
 	var stations []*models.Station
 	for rows.Next() {
 		var s models.Station
-		if err := rows.Scan(&s.Id, &s.ChargeBoxId, &s.ChargeBoxSerial, &s.ChargeBoxVendor, &s.ChargeBoxModel, &s.ChargeBoxFirmware, &s.State); err != nil {
+		if err := rows.Scan(&s.Id, &s.ChargeBoxId, &s.ChargeBoxSerial, &s.ChargeBoxVendor, &s.ChargeBoxModel, &s.ChargeBoxFirmware, &s.State, &s.OcppVersion); err != nil {
 			return nil, err
 		}
 		stations = append(stations, &s)
@@ -58,8 +60,8 @@ func (r *StationRepository) GetAll() ([]*models.Station, error) {
 }
 
 func (r *StationRepository) Update(station *models.Station) error {
-	query := `UPDATE stations SET charge_box_id=?, charge_box_serial=?, charge_box_vendor=?, charge_box_model=?, charge_box_firmware=?, state=? WHERE id=?`
-	_, err := r.db.Exec(query, station.ChargeBoxId, station.ChargeBoxSerial, station.ChargeBoxVendor, station.ChargeBoxModel, station.ChargeBoxFirmware, station.State, station.Id)
+	query := `UPDATE stations SET charge_box_id=?, charge_box_serial=?, charge_box_vendor=?, charge_box_model=?, charge_box_firmware=?, state=?, ocpp_version=? WHERE id=?`
+	_, err := r.db.Exec(query, station.ChargeBoxId, station.ChargeBoxSerial, station.ChargeBoxVendor, station.ChargeBoxModel, station.ChargeBoxFirmware, station.State, station.OcppVersion, station.Id)
 	return err
 }
 
@@ -70,10 +72,10 @@ func (r *StationRepository) Delete(id int) error {
 }
 
 func (r *StationRepository) GetByChargeBoxId(chargeBoxId string) (*models.Station, error) {
-	query := `SELECT id, charge_box_id, charge_box_serial, charge_box_vendor, charge_box_model, charge_box_firmware, state FROM stations WHERE charge_box_id = ?`
+	query := `SELECT id, charge_box_id, charge_box_serial, charge_box_vendor, charge_box_model, charge_box_firmware, state, ocpp_version FROM stations WHERE charge_box_id = ?`
 	row := r.db.QueryRow(query, chargeBoxId)
 	var s models.Station
-	if err := row.Scan(&s.Id, &s.ChargeBoxId, &s.ChargeBoxSerial, &s.ChargeBoxVendor, &s.ChargeBoxModel, &s.ChargeBoxFirmware, &s.State); err != nil {
+	if err := row.Scan(&s.Id, &s.ChargeBoxId, &s.ChargeBoxSerial, &s.ChargeBoxVendor, &s.ChargeBoxModel, &s.ChargeBoxFirmware, &s.State, &s.OcppVersion); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -87,3 +89,45 @@ func (r *StationRepository) SetAllOffline() error {
 	_, err := r.db.Exec(query)
 	return err
 }
+
+func (r *StationRepository) SetOnline(id int, ocppVersion string, connectedAt time.Time) error {
+	query := `UPDATE stations SET state = ?, ocpp_version = ?, connected_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, models.StationStateOnline, ocppVersion, connectedAt.Format("2006-01-02 15:04:05"), id)
+	return err
+}
+
+func (r *StationRepository) SetOffline(id int, disconnectedAt time.Time) error {
+	query := `UPDATE stations SET state = ?, disconnected_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, models.StationStateOffline, disconnectedAt.Format("2006-01-02 15:04:05"), id)
+	return err
+}
+
+// GetLocationID возвращает локацию станции, заполняемую основным приложением
+func (r *StationRepository) GetLocationID(id int) (int, error) {
+	var locationId sql.NullInt64
+	err := r.db.QueryRow(`SELECT location_id FROM stations WHERE id = ?`, id).Scan(&locationId)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return int(locationId.Int64), err
+}
+
+// SetAllCacheClearPending отмечает, что все станции должны очистить кэш авторизации
+func (r *StationRepository) SetAllCacheClearPending() error {
+	_, err := r.db.Exec(`UPDATE stations SET cache_clear_pending = 1`)
+	return err
+}
+
+func (r *StationRepository) GetCacheClearPending(id int) (bool, error) {
+	var pending bool
+	err := r.db.QueryRow(`SELECT cache_clear_pending FROM stations WHERE id = ?`, id).Scan(&pending)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return pending, err
+}
+
+func (r *StationRepository) SetCacheClearPending(id int, pending bool) error {
+	_, err := r.db.Exec(`UPDATE stations SET cache_clear_pending = ? WHERE id = ?`, pending, id)
+	return err
+}
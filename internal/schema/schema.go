@@ -0,0 +1,289 @@
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Официальные JSON-схемы OCPP 1.6J (draft-04): <Action>.json для запроса и <Action>Response.json для ответа
+//
+//go:embed ocpp16/*.json
+var ocpp16Files embed.FS
+
+// Коды нарушений совпадают с кодами ошибок OCPP-J CALLERROR
+const (
+	FormationViolation           = "FormationViolation"
+	PropertyConstraintViolation  = "PropertyConstraintViolation"
+	OccurenceConstraintViolation = "OccurenceConstraintViolation"
+	TypeConstraintViolation      = "TypeConstraintViolation"
+)
+
+// Schema описывает подмножество draft-04, которое используется в схемах OCPP
+type Schema struct {
+	Type                 string             `json:"type"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Enum                 []string           `json:"enum"`
+	MaxLength            *int               `json:"maxLength"`
+	Format               string             `json:"format"`
+	Items                *Schema            `json:"items"`
+	MultipleOf           *float64           `json:"multipleOf"`
+}
+
+// Violation описывает одно нарушение схемы
+type Violation struct {
+	Code    string
+	Path    string
+	Message string
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %s %s", v.Code, v.Path, v.Message)
+}
+
+// Validator хранит схемы запросов и ответов по имени действия
+type Validator struct {
+	requests  map[string]*Schema
+	responses map[string]*Schema
+}
+
+// NewOCPP16 загружает встроенные схемы OCPP 1.6J
+func NewOCPP16() (*Validator, error) {
+	entries, err := ocpp16Files.ReadDir("ocpp16")
+	if err != nil {
+		return nil, err
+	}
+	v := &Validator{
+		requests:  make(map[string]*Schema),
+		responses: make(map[string]*Schema),
+	}
+	for _, entry := range entries {
+		data, err := ocpp16Files.ReadFile(path.Join("ocpp16", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var sc Schema
+		if err := json.Unmarshal(data, &sc); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", entry.Name(), err)
+		}
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		if action, ok := strings.CutSuffix(name, "Response"); ok {
+			v.responses[action] = &sc
+		} else {
+			v.requests[name] = &sc
+		}
+	}
+	return v, nil
+}
+
+// HasRequest сообщает, известна ли схема запроса для действия
+func (v *Validator) HasRequest(action string) bool {
+	_, ok := v.requests[action]
+	return ok
+}
+
+// ValidateRequest проверяет payload CALL. Для неизвестных действий нарушений нет
+func (v *Validator) ValidateRequest(action string, payload interface{}) []Violation {
+	return validate(v.requests[action], payload)
+}
+
+// ValidateResponse проверяет payload CALLRESULT
+func (v *Validator) ValidateResponse(action string, payload interface{}) []Violation {
+	return validate(v.responses[action], payload)
+}
+
+// CoerceRequest приводит типы полей запроса к схеме (строковые числа, числовые строки, булевы строки).
+// Возвращает исправленный payload и список исправленных полей
+func (v *Validator) CoerceRequest(action string, payload interface{}) (interface{}, []string) {
+	sc, ok := v.requests[action]
+	if !ok {
+		return payload, nil
+	}
+	var fixed []string
+	return coerce(sc, payload, "", &fixed), fixed
+}
+
+// ParseDateTime разбирает date-time OCPP. Многие станции 1.6 присылают время без смещения,
+// такое время считается UTC
+func ParseDateTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+	if t, errLocal := time.ParseInLocation("2006-01-02T15:04:05.999999999", value, time.UTC); errLocal == nil {
+		return t, nil
+	}
+	return time.Time{}, err
+}
+
+func validate(sc *Schema, payload interface{}) []Violation {
+	if sc == nil {
+		return nil
+	}
+	var violations []Violation
+	validateValue(sc, payload, "", &violations)
+	return violations
+}
+
+func validateValue(sc *Schema, value interface{}, p string, violations *[]Violation) {
+	add := func(code string, format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Code: code, Path: displayPath(p), Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch sc.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			add(TypeConstraintViolation, "must be an object")
+			return
+		}
+		for _, name := range sc.Required {
+			if _, ok := obj[name]; !ok {
+				*violations = append(*violations, Violation{Code: OccurenceConstraintViolation, Path: displayPath(join(p, name)), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fieldValue := obj[name]
+			fieldSchema, ok := sc.Properties[name]
+			if !ok {
+				if sc.AdditionalProperties != nil && !*sc.AdditionalProperties {
+					*violations = append(*violations, Violation{Code: FormationViolation, Path: displayPath(join(p, name)), Message: "is not allowed"})
+				}
+				continue
+			}
+			validateValue(fieldSchema, fieldValue, join(p, name), violations)
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			add(TypeConstraintViolation, "must be an array")
+			return
+		}
+		if sc.Items != nil {
+			for i, item := range arr {
+				validateValue(sc.Items, item, p+"["+strconv.Itoa(i)+"]", violations)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			add(TypeConstraintViolation, "must be a string")
+			return
+		}
+		if sc.MaxLength != nil && len(str) > *sc.MaxLength {
+			add(PropertyConstraintViolation, "exceeds %d characters", *sc.MaxLength)
+		}
+		if len(sc.Enum) > 0 && !contains(sc.Enum, str) {
+			add(PropertyConstraintViolation, "has invalid value %q", str)
+		}
+		switch sc.Format {
+		case "date-time":
+			if _, err := ParseDateTime(str); err != nil {
+				add(PropertyConstraintViolation, "is not a valid date-time")
+			}
+		case "uri":
+			if u, err := url.Parse(str); err != nil || u.Scheme == "" {
+				add(PropertyConstraintViolation, "is not a valid uri")
+			}
+		}
+	case "integer":
+		num, ok := value.(float64)
+		if !ok || num != math.Trunc(num) {
+			add(TypeConstraintViolation, "must be an integer")
+		}
+	case "number":
+		num, ok := value.(float64)
+		if !ok {
+			add(TypeConstraintViolation, "must be a number")
+			return
+		}
+		if sc.MultipleOf != nil && !isMultipleOf(num, *sc.MultipleOf) {
+			add(PropertyConstraintViolation, "must be a multiple of %v", *sc.MultipleOf)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			add(TypeConstraintViolation, "must be a boolean")
+		}
+	}
+}
+
+func coerce(sc *Schema, value interface{}, p string, fixed *[]string) interface{} {
+	switch sc.Type {
+	case "object":
+		if obj, ok := value.(map[string]interface{}); ok {
+			for name, fieldValue := range obj {
+				if fieldSchema, ok := sc.Properties[name]; ok {
+					obj[name] = coerce(fieldSchema, fieldValue, join(p, name), fixed)
+				}
+			}
+		}
+	case "array":
+		if arr, ok := value.([]interface{}); ok && sc.Items != nil {
+			for i, item := range arr {
+				arr[i] = coerce(sc.Items, item, p+"["+strconv.Itoa(i)+"]", fixed)
+			}
+		}
+	case "integer", "number":
+		if str, ok := value.(string); ok {
+			if num, err := strconv.ParseFloat(strings.TrimSpace(str), 64); err == nil {
+				*fixed = append(*fixed, displayPath(p))
+				return num
+			}
+		}
+	case "string":
+		if num, ok := value.(float64); ok {
+			*fixed = append(*fixed, displayPath(p))
+			return strconv.FormatFloat(num, 'f', -1, 64)
+		}
+	case "boolean":
+		if str, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(str); err == nil {
+				*fixed = append(*fixed, displayPath(p))
+				return b
+			}
+		}
+	}
+	return value
+}
+
+func isMultipleOf(value float64, step float64) bool {
+	q := value / step
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func join(p string, name string) string {
+	if p == "" {
+		return name
+	}
+	return p + "." + name
+}
+
+func displayPath(p string) string {
+	if p == "" {
+		return "payload"
+	}
+	return p
+}
@@ -0,0 +1,178 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+	v, err := NewOCPP16()
+	if err != nil {
+		t.Fatalf("NewOCPP16: %v", err)
+	}
+	return v
+}
+
+func decode(t *testing.T, payload string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(payload), &value); err != nil {
+		t.Fatalf("decode %s: %v", payload, err)
+	}
+	return value
+}
+
+func TestValidateRequest(t *testing.T) {
+	v := newTestValidator(t)
+	tests := []struct {
+		name    string
+		action  string
+		payload string
+		want    []Violation
+	}{
+		{
+			name:    "valid",
+			action:  "StartTransaction",
+			payload: `{"connectorId":1,"idTag":"TAG1","meterStart":1200,"timestamp":"2024-05-01T10:00:00Z"}`,
+		},
+		{
+			name:    "timestamp without offset",
+			action:  "StartTransaction",
+			payload: `{"connectorId":1,"idTag":"TAG1","meterStart":1200,"timestamp":"2024-05-01T10:00:00.123"}`,
+		},
+		{
+			name:    "missing required",
+			action:  "StartTransaction",
+			payload: `{"connectorId":1,"idTag":"TAG1","timestamp":"2024-05-01T10:00:00Z"}`,
+			want:    []Violation{{Code: OccurenceConstraintViolation, Path: "meterStart", Message: "is required"}},
+		},
+		{
+			name:    "wrong type",
+			action:  "StartTransaction",
+			payload: `{"connectorId":"1","idTag":"TAG1","meterStart":1.5,"timestamp":"2024-05-01T10:00:00Z"}`,
+			want: []Violation{
+				{Code: TypeConstraintViolation, Path: "connectorId", Message: "must be an integer"},
+				{Code: TypeConstraintViolation, Path: "meterStart", Message: "must be an integer"},
+			},
+		},
+		{
+			name:    "too long and bad date-time",
+			action:  "StartTransaction",
+			payload: `{"connectorId":1,"idTag":"TAG-LONGER-THAN-TWENTY","meterStart":0,"timestamp":"yesterday"}`,
+			want: []Violation{
+				{Code: PropertyConstraintViolation, Path: "idTag", Message: "exceeds 20 characters"},
+				{Code: PropertyConstraintViolation, Path: "timestamp", Message: "is not a valid date-time"},
+			},
+		},
+		{
+			name:    "additional property",
+			action:  "Heartbeat",
+			payload: `{"extra":true}`,
+			want:    []Violation{{Code: FormationViolation, Path: "extra", Message: "is not allowed"}},
+		},
+		{
+			name:    "invalid enum",
+			action:  "StatusNotification",
+			payload: `{"connectorId":1,"errorCode":"NoError","status":"Sleeping"}`,
+			want:    []Violation{{Code: PropertyConstraintViolation, Path: "status", Message: `has invalid value "Sleeping"`}},
+		},
+		{
+			name:    "not an object",
+			action:  "Heartbeat",
+			payload: `[]`,
+			want:    []Violation{{Code: TypeConstraintViolation, Path: "payload", Message: "must be an object"}},
+		},
+		{
+			name:    "unknown action",
+			action:  "Unknown",
+			payload: `{"anything":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.ValidateRequest(tt.action, decode(t, tt.payload))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoerceRequest(t *testing.T) {
+	v := newTestValidator(t)
+	tests := []struct {
+		name      string
+		action    string
+		payload   string
+		want      string
+		wantFixed []string
+	}{
+		{
+			name:      "string numbers",
+			action:    "StartTransaction",
+			payload:   `{"connectorId":"1","idTag":"TAG1","meterStart":" 1200 ","timestamp":"2024-05-01T10:00:00Z"}`,
+			want:      `{"connectorId":1,"idTag":"TAG1","meterStart":1200,"timestamp":"2024-05-01T10:00:00Z"}`,
+			wantFixed: []string{"connectorId", "meterStart"},
+		},
+		{
+			name:      "numeric string",
+			action:    "Authorize",
+			payload:   `{"idTag":12345}`,
+			want:      `{"idTag":"12345"}`,
+			wantFixed: []string{"idTag"},
+		},
+		{
+			name:    "not a number stays",
+			action:  "StartTransaction",
+			payload: `{"connectorId":"one"}`,
+			want:    `{"connectorId":"one"}`,
+		},
+		{
+			name:    "unknown action",
+			action:  "Unknown",
+			payload: `{"connectorId":"1"}`,
+			want:    `{"connectorId":"1"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fixed := v.CoerceRequest(tt.action, decode(t, tt.payload))
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("CoerceRequest() = %v, want %v", got, want)
+			}
+			sort.Strings(fixed)
+			if !reflect.DeepEqual(fixed, tt.wantFixed) {
+				t.Errorf("CoerceRequest() fixed = %v, want %v", fixed, tt.wantFixed)
+			}
+		})
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "2024-05-01T10:00:00Z", want: "2024-05-01T10:00:00Z"},
+		{value: "2024-05-01T13:00:00+03:00", want: "2024-05-01T10:00:00Z"},
+		{value: "2024-05-01T10:00:00", want: "2024-05-01T10:00:00Z"},
+		{value: "2024-05-01T10:00:00.5", want: "2024-05-01T10:00:00.5Z"},
+		{value: "2024-05-01", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDateTime(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDateTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.UTC().Format("2006-01-02T15:04:05.999999999Z07:00") != tt.want {
+				t.Errorf("ParseDateTime() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"expvar"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+// idTagNotAtThisLocation - idTag не разрешён на станции. В OCPP 1.6 такого статуса нет, станции 1.6 получают Invalid
+const idTagNotAtThisLocation = "NotAtThisLocation"
+
+// idTagConcurrentTx - idTag уже участвует в другой транзакции
+const idTagConcurrentTx = "ConcurrentTx"
+
+// idTagNoCredit - группа idTag израсходовала месячный лимит энергии. В OCPP 1.6 такого статуса нет,
+// станции 1.6 получают Blocked
+const idTagNoCredit = "NoCredit"
+
+// Счётчики решений авторизации доступны на /debug/vars в ключах "<action>:<status>"
+var authorizationDecisions = expvar.NewMap("ocpp_authorization_decisions")
+
+// authorization - решение по idTag. Tag пустой для неизвестных idTag и idTag удалённого запуска
+type authorization struct {
+	Status string
+	Tag    *models.IdTag
+	Reason string
+}
+
+func (a authorization) accepted() bool {
+	return a.Status == models.IdTagAccepted
+}
+
+// idTagInfo переводит решение в idTagInfo OCPP 1.6
+func (a authorization) idTagInfo() IdTagInfo {
+	info := IdTagInfo{Status: a.Status}
+	switch a.Status {
+	case idTagNotAtThisLocation:
+		info.Status = models.IdTagInvalid
+	case idTagNoCredit:
+		info.Status = models.IdTagBlocked
+	}
+	if a.Tag != nil {
+		info.ExpiryDate = idTagExpiryDate(a.Tag)
+		info.ParentIdTag = a.Tag.ParentIdTag
+	}
+	return info
+}
+
+// idTokenInfo201 переводит решение в idTokenInfo OCPP 2.0.1
+func (a authorization) idTokenInfo201() *IdTokenInfo201 {
+	info := &IdTokenInfo201{Status: a.Status}
+	if a.Tag != nil {
+		info.CacheExpiryDateTime = idTagExpiryDate(a.Tag)
+		if a.Tag.ParentIdTag != "" {
+			info.GroupIdToken = &IdToken201{IdToken: a.Tag.ParentIdTag, Type: "Central"}
+		}
+	}
+	return info
+}
+
+// authorizeIdTag проверяет idTag для действия action (Authorize, StartTransaction, TransactionEvent)
+// и логирует решение
+func (s *StationService) authorizeIdTag(action string, idTag string) authorization {
+	a := s.checkIdTag(idTag, startsTransaction(action))
+	userId, parentIdTag := 0, ""
+	if a.Tag != nil {
+		userId, parentIdTag = a.Tag.UserId, a.Tag.ParentIdTag
+	}
+	log.Printf("Авторизация %s: станция %s, idTag %s, пользователь %d, parentIdTag %q: %s (%s)",
+		action, s.chargeBoxId(), idTag, userId, parentIdTag, a.Status, a.Reason)
+	authorizationDecisions.Add(action+":"+a.Status, 1)
+	return a
+}
+
+// startsTransaction сообщает, что action начинает транзакцию. ConcurrentTx в OCPP относится только
+// к началу транзакции: на Authorize карта прикладывается и для остановки своей транзакции
+func startsTransaction(action string) bool {
+	return action == "StartTransaction" || action == "TransactionEvent"
+}
+
+// checkIdTag проверяет idTag. starting - idTag начинает транзакцию, тогда idTag с идущей
+// транзакцией получает ConcurrentTx
+func (s *StationService) checkIdTag(idTag string, starting bool) authorization {
+	// idTag удалённого запуска генерируется для сессии и в id_tags не попадает
+	session, err := s.Repository.Session.GetCurrentSessionByIdTag(idTag)
+	if err != nil {
+		return authorization{Status: models.IdTagInvalid, Reason: fmt.Sprintf("ошибка поиска сессии: %v", err)}
+	}
+	if session != nil && session.StationId == s.Station.Id && session.WasStartTransaction == 0 {
+		return authorization{Status: models.IdTagAccepted, Reason: fmt.Sprintf("сессия %d ожидает начала транзакции", session.Id)}
+	}
+
+	tag, err := s.Repository.IdTag.GetIdTag(idTag)
+	if err != nil {
+		return authorization{Status: models.IdTagInvalid, Reason: fmt.Sprintf("ошибка поиска idTag: %v", err)}
+	}
+	if tag == nil {
+		return authorization{Status: models.IdTagInvalid, Reason: "idTag неизвестен"}
+	}
+	a := authorization{Tag: tag}
+	if tag.Status != models.IdTagAccepted {
+		a.Status, a.Reason = tag.Status, "статус idTag"
+		return a
+	}
+	if !idTagValid(tag, time.Now()) {
+		a.Status, a.Reason = models.IdTagExpired, "истёк срок действия "+tag.ExpiryDate
+		return a
+	}
+	if tag.UserId == 0 && tag.ParentIdTag == "" {
+		a.Status, a.Reason = models.IdTagInvalid, "idTag не привязан к пользователю или группе"
+		return a
+	}
+	allowed, err := s.idTagAllowedHere(tag.IdTag)
+	if err != nil {
+		a.Status, a.Reason = models.IdTagInvalid, fmt.Sprintf("ошибка проверки станций idTag: %v", err)
+		return a
+	}
+	if !allowed {
+		a.Status, a.Reason = idTagNotAtThisLocation, "станция не входит в разрешённые"
+		return a
+	}
+	if starting && session != nil && session.WasStartTransaction == 1 && session.WasStopTransaction == 0 {
+		a.Status, a.Reason = idTagConcurrentTx, fmt.Sprintf("идёт транзакция в сессии %d", session.Id)
+		return a
+	}
+	if tag.ParentIdTag != "" {
+		if status, reason := s.checkIdTagGroup(tag.ParentIdTag, starting); status != "" {
+			a.Status, a.Reason = status, reason
+			return a
+		}
+	}
+	a.Status, a.Reason = models.IdTagAccepted, "idTag действует"
+	return a
+}
+
+// checkIdTagGroup проверяет группу parentIdTag: статус и станции самого parentIdTag, число идущих
+// транзакций (только при начале транзакции, starting) и энергию за текущий месяц.
+// Возвращает пустой статус, если группа не запрещает зарядку
+func (s *StationService) checkIdTagGroup(parentIdTag string, starting bool) (string, string) {
+	parent, err := s.Repository.IdTag.GetIdTag(parentIdTag)
+	if err != nil {
+		return models.IdTagInvalid, fmt.Sprintf("ошибка поиска parentIdTag: %v", err)
+	}
+	if parent != nil {
+		if parent.Status != models.IdTagAccepted {
+			return parent.Status, "статус группы " + parentIdTag
+		}
+		if !idTagValid(parent, time.Now()) {
+			return models.IdTagExpired, "истёк срок действия группы " + parentIdTag
+		}
+	}
+	allowed, err := s.idTagAllowedHere(parentIdTag)
+	if err != nil {
+		return models.IdTagInvalid, fmt.Sprintf("ошибка проверки станций группы: %v", err)
+	}
+	if !allowed {
+		return idTagNotAtThisLocation, "станция не входит в разрешённые группе " + parentIdTag
+	}
+
+	group, err := s.Repository.IdTag.GetIdTagGroup(parentIdTag)
+	if err != nil {
+		return models.IdTagInvalid, fmt.Sprintf("ошибка поиска группы: %v", err)
+	}
+	if group == nil {
+		return "", ""
+	}
+	if starting && group.MaxConcurrentSessions > 0 {
+		active, err := s.Repository.Session.CountGroupActiveSessions(parentIdTag)
+		if err != nil {
+			return models.IdTagInvalid, fmt.Sprintf("ошибка подсчёта транзакций группы: %v", err)
+		}
+		if active >= group.MaxConcurrentSessions {
+			return idTagConcurrentTx, fmt.Sprintf("у группы %s идёт %d транзакций из %d", parentIdTag, active, group.MaxConcurrentSessions)
+		}
+	}
+	if group.MonthlyEnergyKwh > 0 {
+		energy, err := s.Repository.Session.GetGroupEnergy(parentIdTag, monthStart(time.Now()))
+		if err != nil {
+			return models.IdTagInvalid, fmt.Sprintf("ошибка подсчёта энергии группы: %v", err)
+		}
+		if energy >= group.MonthlyEnergyKwh {
+			return idTagNoCredit, fmt.Sprintf("группа %s израсходовала %.2f из %.2f кВт·ч за месяц", parentIdTag, energy, group.MonthlyEnergyKwh)
+		}
+	}
+	return "", ""
+}
+
+// monthStart возвращает начало месяца t в формате времени базы
+func monthStart(t time.Time) string {
+	t = t.UTC().Add(time.Hour * 3)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).Format(dbTimeLayout)
+}
+
+// idTagAllowedHere проверяет ограничения idTag по станциям и локациям
+func (s *StationService) idTagAllowedHere(idTag string) (bool, error) {
+	access, err := s.Repository.IdTag.GetIdTagAccess(idTag)
+	if err != nil {
+		return false, err
+	}
+	if len(access) == 0 {
+		return true, nil
+	}
+	locationId, err := s.Repository.Station.GetLocationID(s.Station.Id)
+	if err != nil {
+		return false, err
+	}
+	return idTagAllowedAt(access, s.Station.Id, locationId), nil
+}
+
+// idTagAllowedAt сообщает, разрешают ли ограничения access станцию stationId в локации locationId
+func idTagAllowedAt(access []*models.IdTagAccess, stationId int, locationId int) bool {
+	if len(access) == 0 {
+		return true
+	}
+	for _, a := range access {
+		if a.StationId != 0 && a.StationId == stationId {
+			return true
+		}
+		if a.LocationId != 0 && a.LocationId == locationId {
+			return true
+		}
+	}
+	return false
+}
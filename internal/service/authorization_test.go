@@ -0,0 +1,268 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+)
+
+const (
+	testStationId  = 1
+	testLocationId = 10
+)
+
+// Фейковые репозитории реализуют только методы, нужные авторизации
+type fakeIdTags struct {
+	repository.IdTag
+	tags   map[string]*models.IdTag
+	access map[string][]*models.IdTagAccess
+	groups map[string]*models.IdTagGroup
+}
+
+func (f *fakeIdTags) GetIdTag(idTag string) (*models.IdTag, error) {
+	return f.tags[idTag], nil
+}
+
+func (f *fakeIdTags) GetIdTagAccess(idTag string) ([]*models.IdTagAccess, error) {
+	return f.access[idTag], nil
+}
+
+func (f *fakeIdTags) GetIdTagGroup(parentIdTag string) (*models.IdTagGroup, error) {
+	return f.groups[parentIdTag], nil
+}
+
+type fakeSessions struct {
+	repository.Session
+	byIdTag map[string]*models.Session
+	active  map[string]int
+	energy  map[string]float64
+}
+
+func (f *fakeSessions) GetCurrentSessionByIdTag(idTag string) (*models.Session, error) {
+	return f.byIdTag[idTag], nil
+}
+
+func (f *fakeSessions) CountGroupActiveSessions(parentIdTag string) (int, error) {
+	return f.active[parentIdTag], nil
+}
+
+func (f *fakeSessions) GetGroupEnergy(parentIdTag string, since string) (float64, error) {
+	return f.energy[parentIdTag], nil
+}
+
+type fakeStations struct {
+	repository.Station
+}
+
+func (fakeStations) GetLocationID(id int) (int, error) {
+	return testLocationId, nil
+}
+
+func newTestStationService(idTags *fakeIdTags, sessions *fakeSessions) *StationService {
+	return &StationService{
+		Station: &models.Station{Id: testStationId, ChargeBoxId: "CP-TEST"},
+		Repository: &repository.Repository{
+			IdTag:   idTags,
+			Session: sessions,
+			Station: fakeStations{},
+		},
+	}
+}
+
+func acceptedTag(idTag string, userId int, parentIdTag string) *models.IdTag {
+	return &models.IdTag{IdTag: idTag, UserId: userId, ParentIdTag: parentIdTag, Status: models.IdTagAccepted}
+}
+
+func TestCheckIdTag(t *testing.T) {
+	expired := acceptedTag("EXPIRED", 1, "")
+	expired.ExpiryDate = toDBTime(time.Now().Add(-time.Hour))
+	valid := acceptedTag("VALID", 1, "")
+	valid.ExpiryDate = toDBTime(time.Now().Add(time.Hour))
+	blocked := acceptedTag("BLOCKED", 1, "")
+	blocked.Status = models.IdTagBlocked
+
+	idTags := &fakeIdTags{
+		tags: map[string]*models.IdTag{
+			"USER":       acceptedTag("USER", 1, ""),
+			"VALID":      valid,
+			"EXPIRED":    expired,
+			"BLOCKED":    blocked,
+			"NOOWNER":    acceptedTag("NOOWNER", 0, ""),
+			"OTHER-ST":   acceptedTag("OTHER-ST", 1, ""),
+			"LOCATION":   acceptedTag("LOCATION", 1, ""),
+			"CHARGING":   acceptedTag("CHARGING", 1, ""),
+			"GROUP-ONLY": acceptedTag("GROUP-ONLY", 0, "FLEET"),
+		},
+		access: map[string][]*models.IdTagAccess{
+			"OTHER-ST": {{IdTag: "OTHER-ST", StationId: 2}},
+			"LOCATION": {{IdTag: "LOCATION", LocationId: testLocationId}},
+		},
+	}
+	sessions := &fakeSessions{
+		byIdTag: map[string]*models.Session{
+			"REMOTE":   {Id: 5, StationId: testStationId},
+			"ELSE":     {Id: 6, StationId: 2},
+			"CHARGING": {Id: 7, StationId: 2, WasStartTransaction: 1},
+		},
+	}
+	s := newTestStationService(idTags, sessions)
+
+	tests := []struct {
+		idTag    string
+		starting bool
+		want     string
+		wantTag  bool
+	}{
+		{idTag: "REMOTE", want: models.IdTagAccepted},
+		{idTag: "ELSE", want: models.IdTagInvalid},
+		{idTag: "UNKNOWN", want: models.IdTagInvalid},
+		{idTag: "USER", want: models.IdTagAccepted, wantTag: true},
+		{idTag: "VALID", want: models.IdTagAccepted, wantTag: true},
+		{idTag: "EXPIRED", want: models.IdTagExpired, wantTag: true},
+		{idTag: "BLOCKED", want: models.IdTagBlocked, wantTag: true},
+		{idTag: "NOOWNER", want: models.IdTagInvalid, wantTag: true},
+		{idTag: "OTHER-ST", want: idTagNotAtThisLocation, wantTag: true},
+		{idTag: "LOCATION", want: models.IdTagAccepted, wantTag: true},
+		{idTag: "CHARGING", starting: true, want: idTagConcurrentTx, wantTag: true},
+		{idTag: "CHARGING", want: models.IdTagAccepted, wantTag: true},
+		{idTag: "GROUP-ONLY", want: models.IdTagAccepted, wantTag: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/starting=%v", tt.idTag, tt.starting), func(t *testing.T) {
+			got := s.checkIdTag(tt.idTag, tt.starting)
+			if got.Status != tt.want {
+				t.Errorf("checkIdTag(%s) = %s (%s), want %s", tt.idTag, got.Status, got.Reason, tt.want)
+			}
+			if (got.Tag != nil) != tt.wantTag {
+				t.Errorf("checkIdTag(%s) tag = %v, want tag %v", tt.idTag, got.Tag, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestIdTagAllowedAt(t *testing.T) {
+	tests := []struct {
+		name   string
+		access []*models.IdTagAccess
+		want   bool
+	}{
+		{name: "no restrictions", want: true},
+		{name: "this station", access: []*models.IdTagAccess{{StationId: testStationId}}, want: true},
+		{name: "other station", access: []*models.IdTagAccess{{StationId: 2}}, want: false},
+		{name: "this location", access: []*models.IdTagAccess{{LocationId: testLocationId}}, want: true},
+		{name: "other location", access: []*models.IdTagAccess{{LocationId: 11}}, want: false},
+		{name: "any of several", access: []*models.IdTagAccess{{StationId: 2}, {LocationId: testLocationId}}, want: true},
+		{name: "empty record", access: []*models.IdTagAccess{{}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idTagAllowedAt(tt.access, testStationId, testLocationId); got != tt.want {
+				t.Errorf("idTagAllowedAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckIdTagGroup(t *testing.T) {
+	expiredParent := acceptedTag("EXPIRED", 0, "")
+	expiredParent.ExpiryDate = toDBTime(time.Now().Add(-time.Hour))
+	blockedParent := acceptedTag("BLOCKED", 0, "")
+	blockedParent.Status = models.IdTagBlocked
+
+	idTags := &fakeIdTags{
+		tags: map[string]*models.IdTag{
+			"FLEET":   acceptedTag("FLEET", 0, ""),
+			"EXPIRED": expiredParent,
+			"BLOCKED": blockedParent,
+			"MEMBER":  acceptedTag("MEMBER", 1, "BLOCKED"),
+		},
+		access: map[string][]*models.IdTagAccess{
+			"ELSEWHERE": {{IdTag: "ELSEWHERE", StationId: 2}},
+			"HERE":      {{IdTag: "HERE", LocationId: testLocationId}},
+		},
+		groups: map[string]*models.IdTagGroup{
+			"FLEET":     {ParentIdTag: "FLEET"},
+			"BUSY":      {ParentIdTag: "BUSY", MaxConcurrentSessions: 2},
+			"FREE":      {ParentIdTag: "FREE", MaxConcurrentSessions: 2},
+			"SPENT":     {ParentIdTag: "SPENT", MonthlyEnergyKwh: 100},
+			"CREDIT":    {ParentIdTag: "CREDIT", MonthlyEnergyKwh: 100},
+			"ELSEWHERE": {ParentIdTag: "ELSEWHERE"},
+			"HERE":      {ParentIdTag: "HERE", MaxConcurrentSessions: 1},
+			"UNLIMITED": {ParentIdTag: "UNLIMITED"},
+		},
+	}
+	sessions := &fakeSessions{
+		active: map[string]int{"BUSY": 2, "FREE": 1, "UNLIMITED": 50},
+		energy: map[string]float64{"SPENT": 100, "CREDIT": 99.5, "UNLIMITED": 1e6},
+	}
+	s := newTestStationService(idTags, sessions)
+
+	tests := []struct {
+		parentIdTag string
+		starting    bool
+		want        string
+	}{
+		{parentIdTag: "FLEET", want: ""},
+		{parentIdTag: "NO-GROUP", want: ""},
+		{parentIdTag: "EXPIRED", want: models.IdTagExpired},
+		{parentIdTag: "BLOCKED", want: models.IdTagBlocked},
+		{parentIdTag: "ELSEWHERE", want: idTagNotAtThisLocation},
+		{parentIdTag: "HERE", want: ""},
+		{parentIdTag: "BUSY", starting: true, want: idTagConcurrentTx},
+		{parentIdTag: "BUSY", want: ""},
+		{parentIdTag: "FREE", starting: true, want: ""},
+		{parentIdTag: "SPENT", want: idTagNoCredit},
+		{parentIdTag: "CREDIT", want: ""},
+		{parentIdTag: "UNLIMITED", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/starting=%v", tt.parentIdTag, tt.starting), func(t *testing.T) {
+			got, reason := s.checkIdTagGroup(tt.parentIdTag, tt.starting)
+			if got != tt.want {
+				t.Errorf("checkIdTagGroup(%s) = %q (%s), want %q", tt.parentIdTag, got, reason, tt.want)
+			}
+		})
+	}
+
+	// Карта группы получает решение группы
+	if got := s.checkIdTag("MEMBER", true); got.Status != models.IdTagBlocked {
+		t.Errorf("checkIdTag(MEMBER) = %s (%s), want %s", got.Status, got.Reason, models.IdTagBlocked)
+	}
+}
+
+func TestIdTagGroupRestricted(t *testing.T) {
+	group := func(sessions int, energy float64) *models.IdTagGroup {
+		return &models.IdTagGroup{ParentIdTag: "FLEET", MaxConcurrentSessions: sessions, MonthlyEnergyKwh: energy}
+	}
+	station := func(id int) *models.IdTagAccess { return &models.IdTagAccess{IdTag: "FLEET", StationId: id} }
+	tests := []struct {
+		name           string
+		previous       *models.IdTagGroup
+		group          *models.IdTagGroup
+		previousAccess []*models.IdTagAccess
+		access         []*models.IdTagAccess
+		want           bool
+	}{
+		{name: "new group without limits", group: group(0, 0), want: false},
+		{name: "new group with limit", group: group(2, 0), want: true},
+		{name: "unchanged", previous: group(2, 50), group: group(2, 50), want: false},
+		{name: "limit raised", previous: group(2, 50), group: group(3, 60), want: false},
+		{name: "limit removed", previous: group(2, 50), group: group(0, 0), want: false},
+		{name: "sessions lowered", previous: group(3, 0), group: group(2, 0), want: true},
+		{name: "energy limit added", previous: group(0, 0), group: group(0, 10), want: true},
+		{name: "access added", previous: group(0, 0), group: group(0, 0), access: []*models.IdTagAccess{station(1)}, want: true},
+		{name: "access removed", previous: group(0, 0), group: group(0, 0), previousAccess: []*models.IdTagAccess{station(1)}, want: false},
+		{name: "access widened", previous: group(0, 0), group: group(0, 0), previousAccess: []*models.IdTagAccess{station(1)}, access: []*models.IdTagAccess{station(1), station(2)}, want: false},
+		{name: "access narrowed", previous: group(0, 0), group: group(0, 0), previousAccess: []*models.IdTagAccess{station(1), station(2)}, access: []*models.IdTagAccess{station(1)}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idTagGroupRestricted(tt.previous, tt.group, tt.previousAccess, tt.access); got != tt.want {
+				t.Errorf("idTagGroupRestricted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
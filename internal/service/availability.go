@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+)
+
+type ChangeAvailabilityRequest struct {
+	ConnectorId int    `json:"connectorId"`
+	Type        string `json:"type"`
+}
+
+type ChangeAvailabilityResponse struct {
+	Status string `json:"status"`
+}
+
+// ChangeAvailabilityRequest201 - без evse команда относится ко всей станции
+type ChangeAvailabilityRequest201 struct {
+	OperationalStatus string   `json:"operationalStatus"`
+	Evse              *EVSE201 `json:"evse,omitempty"`
+}
+
+// availabilityType переводит тип из gRPC в значение OCPP
+func availabilityType(t control.AvailabilityType) string {
+	if t == control.AvailabilityType_inoperative {
+		return models.AvailabilityInoperative
+	}
+	return models.AvailabilityOperative
+}
+
+// saveAvailability запоминает желаемую доступность, чтобы повторить её после перезагрузки станции
+func saveAvailability(repo repository.Availability, stationId int, connectorId int, availability string) error {
+	return repo.SetAvailability(&models.Availability{
+		StationId:    stationId,
+		ConnectorId:  connectorId,
+		Availability: availability,
+		UpdatedAt:    toDBTime(time.Now()),
+	})
+}
+
+// sendChangeAvailability отправляет станции ChangeAvailability. Scheduled считается успехом:
+// станция применит изменение после завершения транзакции
+func (s *StationService) sendChangeAvailability(connectorId int, availability string) (int, string, error) {
+	var req interface{}
+	if s.ocppVersion == models.OcppVersion201 {
+		req201 := ChangeAvailabilityRequest201{OperationalStatus: availability}
+		if connectorId > 0 {
+			req201.Evse = &EVSE201{Id: connectorId}
+		}
+		req = req201
+	} else {
+		req = ChangeAvailabilityRequest{ConnectorId: connectorId, Type: availability}
+	}
+
+	res := &ChangeAvailabilityResponse{}
+	if err := s.sendRequest("ChangeAvailability", req, res); err != nil {
+		return sendErrorCode(err), "", err
+	}
+	log.Printf("ChangeAvailability ответ: %+v", res)
+
+	// Новое состояние коннектора придёт в StatusNotification
+	switch res.Status {
+	case "Accepted":
+		return 0, res.Status, nil
+	case "Scheduled":
+		log.Printf("Станция %s: изменение доступности коннектора %d отложено до конца транзакции", s.chargeBoxId(), connectorId)
+		return 0, res.Status, nil
+	}
+	return int(control.ErrorCode_commandWasNotAccepted), res.Status, fmt.Errorf("ChangeAvailability status: %s", res.Status)
+}
+
+// reapplyAvailability повторно отправляет сохранённую доступность после загрузки станции
+func (s *StationService) reapplyAvailability() {
+	availabilities, err := s.Repository.Availability.GetAvailabilityByStationID(s.Station.Id)
+	if err != nil {
+		log.Printf("Ошибка получения доступности станции %d: %v", s.Station.Id, err)
+		return
+	}
+	for _, a := range availabilities {
+		if _, _, err := s.sendChangeAvailability(a.ConnectorId, a.Availability); err != nil {
+			log.Printf("Станция %s: не удалось повторно применить доступность %s коннектора %d: %v", s.chargeBoxId(), a.Availability, a.ConnectorId, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"log"
+	"sync"
+)
+
+// Ожидающие BootNotification по id станции. После Reset станция переподключается
+// новым соединением, поэтому ожидание хранится вне StationService
+var (
+	bootWaiters   = make(map[int][]chan struct{})
+	bootWaitersMu sync.Mutex
+)
+
+// waitForBoot подписывается на следующий BootNotification станции.
+// Возвращённую функцию нужно вызвать, чтобы отписаться
+func waitForBoot(stationId int) (<-chan struct{}, func()) {
+	ch := make(chan struct{})
+	bootWaitersMu.Lock()
+	bootWaiters[stationId] = append(bootWaiters[stationId], ch)
+	bootWaitersMu.Unlock()
+	return ch, func() {
+		bootWaitersMu.Lock()
+		defer bootWaitersMu.Unlock()
+		waiters := bootWaiters[stationId]
+		for i, w := range waiters {
+			if w == ch {
+				bootWaiters[stationId] = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(bootWaiters[stationId]) == 0 {
+			delete(bootWaiters, stationId)
+		}
+	}
+}
+
+// onBootNotification вызывается после принятого BootNotification любой версии OCPP
+func (s *StationService) onBootNotification() {
+	bootWaitersMu.Lock()
+	waiters := bootWaiters[s.Station.Id]
+	delete(bootWaiters, s.Station.Id)
+	bootWaitersMu.Unlock()
+	if len(waiters) > 0 {
+		log.Printf("Станция %s загрузилась, уведомляем ожидающих: %d", s.chargeBoxId(), len(waiters))
+	}
+	for _, ch := range waiters {
+		close(ch)
+	}
+	s.verifyFirmware()
+	s.registered.Store(true)
+	// Станция принимает команды только после ответа на BootNotification
+	s.afterResponse = append(s.afterResponse, s.afterBoot)
+}
+
+// afterBoot приводит станцию к сохранённому желаемому состоянию после загрузки
+func (s *StationService) afterBoot() {
+	s.reapplyAvailability()
+	s.enforceConfigurationProfile()
+	s.syncLocalListOnConnect()
+	s.clearPendingCache()
+}
+
+// afterReconnect вызывается после ответа на первый CALL соединения, если это не BootNotification:
+// станция переподключилась без перезагрузки и принимает команды, но статусы сама не присылает
+func (s *StationService) afterReconnect() {
+	if !s.registered.CompareAndSwap(false, true) {
+		return
+	}
+	s.triggerStatusOnConnect()
+	s.syncLocalListOnConnect()
+	s.clearPendingCache()
+}
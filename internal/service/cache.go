@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+)
+
+type ClearCacheRequest struct{}
+
+type ClearCacheResponse struct {
+	Status string `json:"status"`
+}
+
+// sendClearCache очищает кэш авторизации станции
+func (s *StationService) sendClearCache() (int, string, error) {
+	res := &ClearCacheResponse{}
+	if err := s.sendRequest("ClearCache", ClearCacheRequest{}, res); err != nil {
+		return sendErrorCode(err), "", err
+	}
+	log.Printf("ClearCache ответ: %+v", res)
+	if res.Status != "Accepted" {
+		return int(control.ErrorCode_commandWasNotAccepted), res.Status, fmt.Errorf("ClearCache status: %s", res.Status)
+	}
+	return 0, res.Status, nil
+}
+
+// setAuthorizationCache включает или выключает кэш авторизации. Выключенный кэш очищается,
+// чтобы при повторном включении станция не использовала устаревшие записи
+func (s *StationService) setAuthorizationCache(enabled bool) (int, string, error) {
+	code, status, err := s.sendChangeConfiguration("AuthorizationCacheEnabled", strconv.FormatBool(enabled))
+	if code != 0 || enabled {
+		return code, status, err
+	}
+	if _, _, err := s.sendClearCache(); err != nil {
+		log.Printf("Станция %s: не удалось очистить выключенный кэш авторизации: %v", s.chargeBoxId(), err)
+	}
+	return code, status, nil
+}
+
+// clearAuthorizationCaches очищает кэш авторизации всех станций, чтобы заблокированный idTag
+// не принимался из кэша. Отметка сохраняется в базе: станции без связи очищаются при подключении
+func clearAuthorizationCaches(repo repository.Station) {
+	if err := repo.SetAllCacheClearPending(); err != nil {
+		log.Printf("Ошибка отметки очистки кэша авторизации станций: %v", err)
+	}
+	for _, service := range ConnectedStationServices() {
+		if service.Station == nil {
+			continue
+		}
+		service.clearPendingCache()
+	}
+}
+
+// clearPendingCache очищает кэш авторизации станции, если очистка отмечена.
+// Отметка снимается, только когда станция приняла ClearCache
+func (s *StationService) clearPendingCache() {
+	if s.Station == nil {
+		return
+	}
+	pending, err := s.Repository.Station.GetCacheClearPending(s.Station.Id)
+	if err != nil {
+		log.Printf("Станция %s: ошибка проверки отметки очистки кэша: %v", s.chargeBoxId(), err)
+		return
+	}
+	if !pending {
+		return
+	}
+	if _, _, err := s.sendClearCache(); err != nil {
+		log.Printf("Станция %s: не удалось очистить кэш авторизации: %v", s.chargeBoxId(), err)
+		return
+	}
+	if err := s.Repository.Station.SetCacheClearPending(s.Station.Id, false); err != nil {
+		log.Printf("Станция %s: ошибка снятия отметки очистки кэша: %v", s.chargeBoxId(), err)
+	}
+}
+
+// propagateIdTag рассылает изменение idTag подключённым станциям. blocked - idTag перестал быть принятым
+func propagateIdTag(repo *repository.Repository, tag *models.IdTag, blocked bool) {
+	syncConnectedLocalLists()
+	if blocked {
+		log.Printf("idTag %s: %s, очищаем кэш авторизации станций", tag.IdTag, tag.Status)
+		clearAuthorizationCaches(repo.Station)
+	}
+}
+
+// propagateIdTagGroup рассылает изменение группы parentIdTag подключённым станциям.
+// restricted - группа стала строже, и принятые раньше карты могут остаться в кэше станций
+func propagateIdTagGroup(repo *repository.Repository, parentIdTag string, restricted bool) {
+	syncConnectedLocalLists()
+	if restricted {
+		log.Printf("Группа %s стала строже, очищаем кэш авторизации станций", parentIdTag)
+		clearAuthorizationCaches(repo.Station)
+	}
+}
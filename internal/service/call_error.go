@@ -0,0 +1,163 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/schema"
+)
+
+// Коды ошибок OCPP-J CALLERROR
+const (
+	callErrorNotImplemented               = "NotImplemented"
+	callErrorNotSupported                 = "NotSupported"
+	callErrorInternalError                = "InternalError"
+	callErrorProtocolError                = "ProtocolError"
+	callErrorFormationViolation           = "FormationViolation"
+	callErrorPropertyConstraintViolation  = "PropertyConstraintViolation"
+	callErrorOccurenceConstraintViolation = "OccurenceConstraintViolation"
+	callErrorTypeConstraintViolation      = "TypeConstraintViolation"
+	callErrorGenericError                 = "GenericError"
+)
+
+// CallError описывает OCPP-J CALLERROR (messageTypeId = 4)
+
+type CallError struct {
+	ErrorCode        string          `json:"errorCode"`
+	ErrorDescription string          `json:"errorDescription"`
+	ErrorDetails     json.RawMessage `json:"errorDetails,omitempty"`
+}
+
+func (e *CallError) Error() string {
+	if e.ErrorDescription == "" {
+		return fmt.Sprintf("CALLERROR %s", e.ErrorCode)
+	}
+	return fmt.Sprintf("CALLERROR %s: %s", e.ErrorCode, e.ErrorDescription)
+}
+
+func newCallError(code string, description string, details map[string]interface{}) *CallError {
+	callErr := &CallError{ErrorCode: code, ErrorDescription: description}
+	if details != nil {
+		if b, err := json.Marshal(details); err == nil {
+			callErr.ErrorDetails = b
+		}
+	}
+	return callErr
+}
+
+// parseCallError разбирает элементы CALLERROR [4, uniqueId, errorCode, errorDescription, errorDetails]
+func parseCallError(ocppMsg []interface{}) *CallError {
+	callErr := &CallError{}
+	callErr.ErrorCode, _ = ocppMsg[2].(string)
+	if len(ocppMsg) > 3 {
+		callErr.ErrorDescription, _ = ocppMsg[3].(string)
+	}
+	if len(ocppMsg) > 4 && ocppMsg[4] != nil {
+		if b, err := json.Marshal(ocppMsg[4]); err == nil {
+			callErr.ErrorDetails = b
+		}
+	}
+	return callErr
+}
+
+// ocppValidator реализуется запросами, для которых нет JSON-схемы (OCPP 2.0.1): Validate() проверяет
+// ограничения на значения полей. Запросы OCPP 1.6J проверяет только validationMiddleware по схемам
+type ocppValidator interface {
+	Validate() error
+}
+
+// decodeCallPayload разбирает payload CALL в структуру запроса и проверяет его
+func decodeCallPayload(payload map[string]interface{}, req interface{}) *CallError {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return newCallError(callErrorFormationViolation, err.Error(), nil)
+	}
+	if err := json.Unmarshal(payloadBytes, req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return newCallError(callErrorTypeConstraintViolation,
+				fmt.Sprintf("field %s must be %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value),
+				map[string]interface{}{"field": typeErr.Field})
+		}
+		return newCallError(callErrorFormationViolation, err.Error(), nil)
+	}
+	if v, ok := req.(ocppValidator); ok {
+		if err := v.Validate(); err != nil {
+			var callErr *CallError
+			if errors.As(err, &callErr) {
+				return callErr
+			}
+			return newCallError(callErrorPropertyConstraintViolation, err.Error(), nil)
+		}
+	}
+	return nil
+}
+
+// Вспомогательные проверки для Validate()
+
+func requireString(field string, value string, maxLength int) error {
+	if value == "" {
+		return newCallError(callErrorOccurenceConstraintViolation, fmt.Sprintf("field %s is required", field),
+			map[string]interface{}{"field": field})
+	}
+	return checkMaxLength(field, value, maxLength)
+}
+
+func checkMaxLength(field string, value string, maxLength int) error {
+	if len(value) > maxLength {
+		return newCallError(callErrorPropertyConstraintViolation, fmt.Sprintf("field %s exceeds %d characters", field, maxLength),
+			map[string]interface{}{"field": field})
+	}
+	return nil
+}
+
+func checkMin(field string, value int, min int) error {
+	if value < min {
+		return newCallError(callErrorPropertyConstraintViolation, fmt.Sprintf("field %s must be at least %d", field, min),
+			map[string]interface{}{"field": field})
+	}
+	return nil
+}
+
+func checkEnum(field string, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return newCallError(callErrorPropertyConstraintViolation, fmt.Sprintf("field %s has invalid value %q", field, value),
+		map[string]interface{}{"field": field})
+}
+
+func checkTimestamp(field string, value string) error {
+	if value == "" {
+		return newCallError(callErrorOccurenceConstraintViolation, fmt.Sprintf("field %s is required", field),
+			map[string]interface{}{"field": field})
+	}
+	if _, err := schema.ParseDateTime(value); err != nil {
+		return newCallError(callErrorPropertyConstraintViolation, fmt.Sprintf("field %s is not a valid date-time", field),
+			map[string]interface{}{"field": field})
+	}
+	return nil
+}
+
+// sendCallError отправляет станции CALLERROR в ответ на её CALL
+func (s *StationService) sendCallError(uniqueId string, callErr *CallError) {
+	log.Printf("Отправляем CALLERROR на %s: %s", uniqueId, callErr.Error())
+	details := json.RawMessage("{}")
+	if len(callErr.ErrorDetails) > 0 {
+		details = callErr.ErrorDetails
+	}
+	errorCode := callErr.ErrorCode
+	if code, ok := ocpp201ErrorCodes[errorCode]; ok && s.ocppVersion == models.OcppVersion201 {
+		errorCode = code
+	}
+	resp := []interface{}{4, uniqueId, errorCode, callErr.ErrorDescription, details}
+	respBytes, _ := json.Marshal(resp)
+	if err := s.write(respBytes); err != nil {
+		log.Println("Ошибка отправки CALLERROR:", err)
+	}
+}
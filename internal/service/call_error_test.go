@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCallError(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  []interface{}
+		want *CallError
+	}{
+		{
+			name: "full frame",
+			msg:  []interface{}{float64(4), "42", "NotSupported", "RemoteStart disabled", map[string]interface{}{"reason": "config"}},
+			want: &CallError{ErrorCode: "NotSupported", ErrorDescription: "RemoteStart disabled", ErrorDetails: []byte(`{"reason":"config"}`)},
+		},
+		{
+			name: "empty details",
+			msg:  []interface{}{float64(4), "42", "InternalError", "", map[string]interface{}{}},
+			want: &CallError{ErrorCode: "InternalError", ErrorDetails: []byte(`{}`)},
+		},
+		{
+			name: "null details",
+			msg:  []interface{}{float64(4), "42", "GenericError", "failed", nil},
+			want: &CallError{ErrorCode: "GenericError", ErrorDescription: "failed"},
+		},
+		{
+			name: "no description and details",
+			msg:  []interface{}{float64(4), "42", "ProtocolError"},
+			want: &CallError{ErrorCode: "ProtocolError"},
+		},
+		{
+			name: "wrong element types",
+			msg:  []interface{}{float64(4), "42", float64(1), true},
+			want: &CallError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCallError(tt.msg)
+			if got.ErrorCode != tt.want.ErrorCode || got.ErrorDescription != tt.want.ErrorDescription ||
+				string(got.ErrorDetails) != string(tt.want.ErrorDetails) {
+				t.Errorf("parseCallError() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallErrorError(t *testing.T) {
+	if got := (&CallError{ErrorCode: "NotSupported"}).Error(); got != "CALLERROR NotSupported" {
+		t.Errorf("Error() = %q", got)
+	}
+	got := (&CallError{ErrorCode: "InternalError", ErrorDescription: "boom"}).Error()
+	if got != "CALLERROR InternalError: boom" {
+		t.Errorf("Error() = %q", got)
+	}
+	if details := newCallError("GenericError", "x", nil).ErrorDetails; len(details) != 0 {
+		t.Errorf("newCallError() without details has details %s", details)
+	}
+}
+
+// plainValidated возвращает из Validate() обычную ошибку, а не CallError
+type plainValidated struct {
+	N int `json:"n"`
+}
+
+func (r plainValidated) Validate() error {
+	if r.N < 0 {
+		return errors.New("n must not be negative")
+	}
+	return nil
+}
+
+func TestDecodeCallPayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  map[string]interface{}
+		req      interface{}
+		wantCode string
+	}{
+		{
+			name:    "1.6 request without Validate",
+			payload: map[string]interface{}{"connectorId": float64(1), "idTag": "", "meterStart": float64(0)},
+			req:     &StartTransactionRequest{},
+		},
+		{
+			name:     "wrong field type",
+			payload:  map[string]interface{}{"connectorId": "one"},
+			req:      &StartTransactionRequest{},
+			wantCode: callErrorTypeConstraintViolation,
+		},
+		{
+			name:    "valid 2.0.1 request",
+			payload: map[string]interface{}{"idToken": map[string]interface{}{"idToken": "ABC", "type": "ISO14443"}},
+			req:     &AuthorizeRequest201{},
+		},
+		{
+			name:     "missing required field",
+			payload:  map[string]interface{}{"idToken": map[string]interface{}{"type": "ISO14443"}},
+			req:      &AuthorizeRequest201{},
+			wantCode: callErrorOccurenceConstraintViolation,
+		},
+		{
+			name:     "invalid enum value",
+			payload:  map[string]interface{}{"idToken": map[string]interface{}{"idToken": "ABC", "type": "Card"}},
+			req:      &AuthorizeRequest201{},
+			wantCode: callErrorPropertyConstraintViolation,
+		},
+		{
+			name:     "plain Validate error",
+			payload:  map[string]interface{}{"n": float64(-1)},
+			req:      &plainValidated{},
+			wantCode: callErrorPropertyConstraintViolation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callErr := decodeCallPayload(tt.payload, tt.req)
+			if tt.wantCode == "" {
+				if callErr != nil {
+					t.Errorf("decodeCallPayload() = %v, want nil", callErr)
+				}
+				return
+			}
+			if callErr == nil || callErr.ErrorCode != tt.wantCode {
+				t.Errorf("decodeCallPayload() = %v, want %s", callErr, tt.wantCode)
+			}
+		})
+	}
+}
@@ -2,12 +2,20 @@ package service
 
 import (
 	context "context"
+	"errors"
 	"fmt"
+	"io"
+	"time"
 
+	"github.com/delevopersmoke/ocpp_microservice/internal/config"
+	"github.com/delevopersmoke/ocpp_microservice/internal/files"
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
 	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
 	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 // CommandServer реализует CommandServiceServer для gRPC
@@ -15,43 +23,581 @@ import (
 
 type CommandServiceServer struct {
 	control.ControlServiceServer
+	repo  *repository.Repository
+	cfg   *config.Config
+	files *files.Store
 }
 
-func NewCommandServiceServer(repo *repository.Repository) *CommandServiceServer {
-	return &CommandServiceServer{}
+func NewCommandServiceServer(repo *repository.Repository, cfg *config.Config, files *files.Store) *CommandServiceServer {
+	return &CommandServiceServer{repo: repo, cfg: cfg, files: files}
 }
 
 func (s *CommandServiceServer) Start(ctx context.Context, req *control.StartStationRequest) (*control.StartStationResponse, error) {
 	fmt.Println("Starting Station")
-	service, ok := stationServices[int(req.StationId)]
+	service, ok := GetStationService(int(req.StationId))
 	if ok {
-		code := service.sendRemoteStartTransaction(int(req.SessionId))
+		code, err := service.sendRemoteStartTransaction(int(req.SessionId))
 		if code != 0 {
-			return nil, getCustomError(int64(code), fmt.Errorf("Failed to start station: %s", code))
+			return nil, getCustomError(int64(code), fmt.Errorf("Failed to start station: %w", err))
 		} else {
 			return &control.StartStationResponse{Success: true}, nil
 		}
 	} else {
 		fmt.Println("Station not found:", req.StationId)
-		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("password must be at least 6 characters"))
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
 	}
 }
 
 func (s *CommandServiceServer) Stop(ctx context.Context, req *control.StopStationRequest) (*control.StopStationResponse, error) {
-	service, ok := stationServices[int(req.StationId)]
+	service, ok := GetStationService(int(req.StationId))
 	if ok {
-		code := service.sendRemoteStopTransaction(int(req.SessionId))
+		code, err := service.sendRemoteStopTransaction(int(req.SessionId))
 		if code != 0 {
-			return nil, getCustomError(int64(code), fmt.Errorf("Failed to stop station: %s", code))
+			return nil, getCustomError(int64(code), fmt.Errorf("Failed to stop station: %w", err))
 		} else {
 			return &control.StopStationResponse{Success: true}, nil
 		}
 	} else {
 		fmt.Println("Station not found:", req.StationId)
-		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station not found: "))
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
 	}
 }
 
+// defaultBootTimeout - сколько Reset ждёт BootNotification, если boot_timeout_seconds не задан
+const defaultBootTimeout = 120 * time.Second
+
+func (s *CommandServiceServer) Reset(ctx context.Context, req *control.ResetStationRequest) (*control.ResetStationResponse, error) {
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+
+	// Подписываемся до отправки Reset, чтобы не пропустить быстрый BootNotification
+	var booted <-chan struct{}
+	if req.WaitForBoot {
+		ch, cancel := waitForBoot(int(req.StationId))
+		defer cancel()
+		booted = ch
+	}
+
+	code, resetStatus, err := service.sendReset(req.Type)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to reset station: %w", err))
+	}
+	res := &control.ResetStationResponse{Success: true, Status: resetStatus}
+	if !req.WaitForBoot {
+		return res, nil
+	}
+
+	timeout := defaultBootTimeout
+	if req.BootTimeoutSeconds > 0 {
+		timeout = time.Duration(req.BootTimeoutSeconds) * time.Second
+	}
+	select {
+	case <-booted:
+		res.Booted = true
+		return res, nil
+	case <-time.After(timeout):
+		return nil, getCustomError(int64(control.ErrorCode_bootNotificationTimeout), fmt.Errorf("Station %d did not send BootNotification within %s", req.StationId, timeout))
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ChangeAvailability сохраняет желаемую доступность и отправляет её станции.
+// Если станция не подключена, доступность будет применена после её следующей загрузки
+func (s *CommandServiceServer) ChangeAvailability(ctx context.Context, req *control.ChangeAvailabilityRequest) (*control.ChangeAvailabilityResponse, error) {
+	availability := availabilityType(req.Type)
+	if err := saveAvailability(s.repo.Availability, int(req.StationId), int(req.ConnectorId), availability); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to save availability: %w", err))
+	}
+
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, availabilityStatus, err := service.sendChangeAvailability(int(req.ConnectorId), availability)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to change availability: %w", err))
+	}
+	return &control.ChangeAvailabilityResponse{Success: true, Status: availabilityStatus}, nil
+}
+
+// GetConfiguration возвращает конфигурацию станции. При cached ответ строится из сохранённого снимка,
+// поэтому работает и для отключённой станции
+func (s *CommandServiceServer) GetConfiguration(ctx context.Context, req *control.GetConfigurationRequest) (*control.GetConfigurationResponse, error) {
+	if req.Cached {
+		keys, err := s.repo.Configuration.GetConfigurationByStationID(int(req.StationId))
+		if err != nil {
+			return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get configuration: %w", err))
+		}
+		return &control.GetConfigurationResponse{ConfigurationKeys: configurationKeysToProto(filterConfigurationKeys(keys, req.Keys))}, nil
+	}
+
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, keys, unknownKeys, err := service.sendGetConfiguration(req.Keys)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to get configuration: %w", err))
+	}
+	return &control.GetConfigurationResponse{ConfigurationKeys: configurationKeysToProto(keys), UnknownKeys: unknownKeys}, nil
+}
+
+func (s *CommandServiceServer) ChangeConfiguration(ctx context.Context, req *control.ChangeConfigurationRequest) (*control.ChangeConfigurationResponse, error) {
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, configurationStatus, err := service.sendChangeConfiguration(req.Key, req.Value)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to change configuration: %w", err))
+	}
+	return &control.ChangeConfigurationResponse{
+		Success:        true,
+		Status:         configurationStatus,
+		RebootRequired: configurationStatus == "RebootRequired",
+	}, nil
+}
+
+// GetConfigurationDrift строится по сохранённому снимку, поэтому работает и для отключённой станции
+func (s *CommandServiceServer) GetConfigurationDrift(ctx context.Context, req *control.GetConfigurationDriftRequest) (*control.GetConfigurationDriftResponse, error) {
+	station, err := s.repo.Station.GetByID(int(req.StationId))
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get station: %w", err))
+	}
+	if station == nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorUnknown), fmt.Errorf("Station %d not found", req.StationId))
+	}
+	keys, err := s.repo.Configuration.GetConfigurationByStationID(station.Id)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get configuration: %w", err))
+	}
+	profile := s.cfg.ConfigurationProfile(station.ChargeBoxVendor, station.ChargeBoxModel)
+	return &control.GetConfigurationDriftResponse{
+		Vendor: station.ChargeBoxVendor,
+		Model:  station.ChargeBoxModel,
+		Drift:  configurationDriftToProto(diffConfiguration(profile, keys)),
+	}, nil
+}
+
+func (s *CommandServiceServer) UnlockConnector(ctx context.Context, req *control.UnlockConnectorRequest) (*control.UnlockConnectorResponse, error) {
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, unlockStatus, err := service.sendUnlockConnector(int(req.ConnectorId), req.StopTransaction)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to unlock connector: %w", err))
+	}
+	return &control.UnlockConnectorResponse{Success: true, Status: unlockStatus}, nil
+}
+
+func (s *CommandServiceServer) TriggerMessage(ctx context.Context, req *control.TriggerMessageRequest) (*control.TriggerMessageResponse, error) {
+	requestedMessage, ok := triggerMessages[req.RequestedMessage]
+	if !ok {
+		return nil, getCustomError(int64(control.ErrorCode_commandNotSupported), fmt.Errorf("Unknown requested message %d", req.RequestedMessage))
+	}
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, triggerStatus, err := service.sendTriggerMessage(requestedMessage, int(req.ConnectorId))
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to trigger message: %w", err))
+	}
+	return &control.TriggerMessageResponse{Success: true, Status: triggerStatus}, nil
+}
+
+func (s *CommandServiceServer) UpdateFirmware(ctx context.Context, req *control.UpdateFirmwareRequest) (*control.UpdateFirmwareResponse, error) {
+	retrieveDate := time.Now()
+	if req.RetrieveDate != "" {
+		t, err := time.Parse(time.RFC3339, req.RetrieveDate)
+		if err != nil {
+			return nil, getCustomError(int64(control.ErrorCode_invalidArgument), fmt.Errorf("Invalid retrieve_date: %w", err))
+		}
+		retrieveDate = t
+	}
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	location, err := firmwareLocation(s.files, int(req.StationId), req.Location, retrieveDate, int(req.Retries), int(req.RetryInterval))
+	if errors.Is(err, files.ErrNoBaseURL) {
+		return nil, getCustomError(int64(control.ErrorCode_invalidArgument), fmt.Errorf("Failed to update firmware: %w", err))
+	}
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_notFound), fmt.Errorf("Failed to update firmware: %w", err))
+	}
+	code, err := service.sendUpdateFirmware(location, retrieveDate, int(req.Retries), int(req.RetryInterval), int(time.Now().Unix()))
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to update firmware: %w", err))
+	}
+	return &control.UpdateFirmwareResponse{Success: true}, nil
+}
+
+func (s *CommandServiceServer) CreateFirmwareCampaign(ctx context.Context, req *control.CreateFirmwareCampaignRequest) (*control.FirmwareCampaignResponse, error) {
+	campaign, stations, err := newFirmwareCampaign(req)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_invalidArgument), err)
+	}
+	if err := s.repo.Firmware.CreateFirmwareCampaign(campaign, stations); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to create firmware campaign: %w", err))
+	}
+	return firmwareCampaignToProto(campaign, stations), nil
+}
+
+func (s *CommandServiceServer) GetFirmwareCampaign(ctx context.Context, req *control.FirmwareCampaignRequest) (*control.FirmwareCampaignResponse, error) {
+	campaign, stations, err := s.getFirmwareCampaign(int(req.CampaignId))
+	if err != nil {
+		return nil, err
+	}
+	return firmwareCampaignToProto(campaign, stations), nil
+}
+
+func (s *CommandServiceServer) PauseFirmwareCampaign(ctx context.Context, req *control.FirmwareCampaignRequest) (*control.FirmwareCampaignResponse, error) {
+	return s.setFirmwareCampaignStatus(int(req.CampaignId), models.FirmwareCampaignPaused, 0)
+}
+
+func (s *CommandServiceServer) ResumeFirmwareCampaign(ctx context.Context, req *control.ResumeFirmwareCampaignRequest) (*control.FirmwareCampaignResponse, error) {
+	return s.setFirmwareCampaignStatus(int(req.CampaignId), models.FirmwareCampaignRunning, int(req.FailureThreshold))
+}
+
+func (s *CommandServiceServer) getFirmwareCampaign(campaignId int) (*models.FirmwareCampaign, []*models.FirmwareCampaignStation, error) {
+	campaign, err := s.repo.Firmware.GetFirmwareCampaign(campaignId)
+	if err != nil {
+		return nil, nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get firmware campaign: %w", err))
+	}
+	if campaign == nil {
+		return nil, nil, getCustomError(int64(control.ErrorCode_notFound), fmt.Errorf("Firmware campaign %d not found", campaignId))
+	}
+	stations, err := s.repo.Firmware.GetFirmwareCampaignStations(campaignId)
+	if err != nil {
+		return nil, nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get firmware campaign stations: %w", err))
+	}
+	return campaign, stations, nil
+}
+
+// setFirmwareCampaignStatus ставит кампанию на паузу или возобновляет её. Завершённую кампанию менять нельзя
+func (s *CommandServiceServer) setFirmwareCampaignStatus(campaignId int, status string, failureThreshold int) (*control.FirmwareCampaignResponse, error) {
+	campaign, stations, err := s.getFirmwareCampaign(campaignId)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status == models.FirmwareCampaignCompleted {
+		return nil, getCustomError(int64(control.ErrorCode_invalidArgument), fmt.Errorf("Firmware campaign %d is completed", campaignId))
+	}
+	if campaign.Status == models.FirmwareCampaignPaused && status == models.FirmwareCampaignRunning {
+		// Ошибки, из-за которых кампания встала на паузу, уже разобраны: порог отсчитывается заново
+		campaign.ResumedFailures = countFailedStations(stations)
+	}
+	campaign.Status = status
+	if failureThreshold > 0 {
+		campaign.FailureThreshold = failureThreshold
+	}
+	campaign.UpdatedAt = toDBTime(time.Now())
+	if err := s.repo.Firmware.UpdateFirmwareCampaign(campaign); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to update firmware campaign: %w", err))
+	}
+	return firmwareCampaignToProto(campaign, stations), nil
+}
+
+// GetDiagnostics создаёт запрос диагностики и отправляет его станции
+func (s *CommandServiceServer) GetDiagnostics(ctx context.Context, req *control.GetDiagnosticsRequest) (*control.GetDiagnosticsResponse, error) {
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	location := req.Location
+	if location == "" {
+		var err error
+		location, err = s.files.DiagnosticsURL(int(req.StationId), lastAttempt(time.Now(), int(req.Retries), int(req.RetryInterval)))
+		if err != nil {
+			return nil, getCustomError(int64(control.ErrorCode_invalidArgument), fmt.Errorf("Failed to get diagnostics: %w", err))
+		}
+	}
+	request, err := newDiagnosticsRequest(req, location)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_invalidArgument), fmt.Errorf("Invalid diagnostics time: %w", err))
+	}
+	if err := s.repo.Diagnostics.CreateDiagnosticsRequest(request); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to save diagnostics request: %w", err))
+	}
+	code, err := service.sendGetDiagnostics(request)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to get diagnostics: %w", err))
+	}
+	return &control.GetDiagnosticsResponse{Request: diagnosticsRequestToProto(request)}, nil
+}
+
+func (s *CommandServiceServer) ListDiagnostics(ctx context.Context, req *control.ListDiagnosticsRequest) (*control.ListDiagnosticsResponse, error) {
+	requests, err := s.repo.Diagnostics.GetDiagnosticsRequestsByStationID(int(req.StationId))
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get diagnostics requests: %w", err))
+	}
+	diagnosticsFiles, err := s.repo.Diagnostics.GetDiagnosticsFilesByStationID(int(req.StationId))
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get diagnostics files: %w", err))
+	}
+	res := &control.ListDiagnosticsResponse{}
+	for _, request := range requests {
+		res.Requests = append(res.Requests, diagnosticsRequestToProto(request))
+	}
+	for _, file := range diagnosticsFiles {
+		res.Files = append(res.Files, diagnosticsFileToProto(file))
+	}
+	return res, nil
+}
+
+// DownloadDiagnostics передаёт файл диагностики частями по diagnosticsChunkSize
+func (s *CommandServiceServer) DownloadDiagnostics(req *control.DownloadDiagnosticsRequest, stream grpc.ServerStreamingServer[control.DiagnosticsFileChunk]) error {
+	file, err := s.repo.Diagnostics.GetDiagnosticsFile(int(req.FileId))
+	if err != nil {
+		return getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get diagnostics file: %w", err))
+	}
+	if file == nil || file.StationId != int(req.StationId) {
+		return getCustomError(int64(control.ErrorCode_notFound), fmt.Errorf("Diagnostics file %d not found", req.FileId))
+	}
+	f, err := s.files.OpenDiagnostics(file.StationId, file.Name)
+	if err != nil {
+		return getCustomError(int64(control.ErrorCode_notFound), fmt.Errorf("Failed to open diagnostics file: %w", err))
+	}
+	defer f.Close()
+
+	buf := make([]byte, diagnosticsChunkSize)
+	chunk := &control.DiagnosticsFileChunk{Name: file.Name}
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			chunk = &control.DiagnosticsFileChunk{}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return getCustomError(int64(control.ErrorCode_errorUnknown), fmt.Errorf("Failed to read diagnostics file: %w", err))
+		}
+	}
+}
+
+func (s *CommandServiceServer) ReserveNow(ctx context.Context, req *control.ReserveNowRequest) (*control.ReserveNowResponse, error) {
+	reservation, err := newReservation(req)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_invalidArgument), err)
+	}
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	// Запись создаётся до отправки: её id передаётся станции как reservationId
+	if err := s.repo.Reservation.CreateReservation(reservation); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to save reservation: %w", err))
+	}
+	code, err := service.sendReserveNow(reservation)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to reserve connector: %w", err))
+	}
+	return &control.ReserveNowResponse{Success: true, Reservation: reservationToProto(reservation)}, nil
+}
+
+func (s *CommandServiceServer) CancelReservation(ctx context.Context, req *control.CancelReservationRequest) (*control.CancelReservationResponse, error) {
+	reservation, err := s.repo.Reservation.GetReservation(int(req.ReservationId))
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get reservation: %w", err))
+	}
+	if reservation == nil || reservation.StationId != int(req.StationId) {
+		return nil, getCustomError(int64(control.ErrorCode_notFound), fmt.Errorf("Reservation %d not found", req.ReservationId))
+	}
+	if reservation.Status != models.ReservationActive {
+		return nil, getCustomError(int64(control.ErrorCode_invalidArgument), fmt.Errorf("Reservation %d is %s", reservation.Id, reservation.Status))
+	}
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, err := service.sendCancelReservation(reservation)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to cancel reservation: %w", err))
+	}
+	return &control.CancelReservationResponse{Success: true, Reservation: reservationToProto(reservation)}, nil
+}
+
+func (s *CommandServiceServer) ListReservations(ctx context.Context, req *control.ListReservationsRequest) (*control.ListReservationsResponse, error) {
+	reservations, err := s.repo.Reservation.GetReservationsByStationID(int(req.StationId))
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get reservations: %w", err))
+	}
+	res := &control.ListReservationsResponse{}
+	for _, reservation := range reservations {
+		res.Reservations = append(res.Reservations, reservationToProto(reservation))
+	}
+	return res, nil
+}
+
+func (s *CommandServiceServer) SetIdTag(ctx context.Context, req *control.SetIdTagRequest) (*control.SetIdTagResponse, error) {
+	tag, err := newIdTag(req)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_invalidArgument), err)
+	}
+	previous, err := s.repo.IdTag.GetIdTag(tag.IdTag)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get id tag: %w", err))
+	}
+	access := newIdTagAccess(req.IdTag, req.AllowedStationIds, req.AllowedLocationIds)
+	// Ограничения сохраняются первыми: станции получат их вместе с новой версией idTag
+	if err := s.repo.IdTag.ReplaceIdTagAccess(tag.IdTag, access); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to save id tag access: %w", err))
+	}
+	if err := s.repo.IdTag.SaveIdTag(tag); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to save id tag: %w", err))
+	}
+	// Станции могли закэшировать idTag как принятый: после блокировки кэш нужно очистить
+	blocked := tag.Status != models.IdTagAccepted && (previous == nil || previous.Status == models.IdTagAccepted)
+	go propagateIdTag(s.repo, tag, blocked)
+	return &control.SetIdTagResponse{IdTag: idTagToProto(tag, access)}, nil
+}
+
+func (s *CommandServiceServer) SyncLocalList(ctx context.Context, req *control.SyncLocalListRequest) (*control.SyncLocalListResponse, error) {
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, version, err := service.syncLocalList(req.Full)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to sync local list: %w", err))
+	}
+	return &control.SyncLocalListResponse{Success: true, Version: int64(version)}, nil
+}
+
+func (s *CommandServiceServer) GetLocalListVersion(ctx context.Context, req *control.GetLocalListVersionRequest) (*control.GetLocalListVersionResponse, error) {
+	listVersion, err := s.repo.IdTag.GetIdTagListVersion()
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get local list version: %w", err))
+	}
+	synced, err := s.repo.IdTag.GetLocalListVersion(int(req.StationId))
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get local list version: %w", err))
+	}
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, stationVersion, err := service.sendGetLocalListVersion()
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to get local list version: %w", err))
+	}
+	return &control.GetLocalListVersionResponse{
+		StationVersion: int64(stationVersion),
+		SyncedVersion:  int64(synced),
+		ListVersion:    int64(listVersion),
+	}, nil
+}
+
+func (s *CommandServiceServer) ClearCache(ctx context.Context, req *control.ClearCacheRequest) (*control.ClearCacheResponse, error) {
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, cacheStatus, err := service.sendClearCache()
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to clear cache: %w", err))
+	}
+	return &control.ClearCacheResponse{Success: true, Status: cacheStatus}, nil
+}
+
+func (s *CommandServiceServer) SetAuthorizationCache(ctx context.Context, req *control.SetAuthorizationCacheRequest) (*control.SetAuthorizationCacheResponse, error) {
+	service, ok := GetStationService(int(req.StationId))
+	if !ok {
+		fmt.Println("Station not found:", req.StationId)
+		return nil, getCustomError(int64(control.ErrorCode_stationNotConnected), fmt.Errorf("Station %d is not connected", req.StationId))
+	}
+	code, configurationStatus, err := service.setAuthorizationCache(req.Enabled)
+	if code != 0 {
+		return nil, getCustomError(int64(code), fmt.Errorf("Failed to set authorization cache: %w", err))
+	}
+	return &control.SetAuthorizationCacheResponse{
+		Success:        true,
+		Status:         configurationStatus,
+		RebootRequired: configurationStatus == "RebootRequired",
+	}, nil
+}
+
+func (s *CommandServiceServer) SetIdTagGroup(ctx context.Context, req *control.SetIdTagGroupRequest) (*control.IdTagGroupResponse, error) {
+	group, err := newIdTagGroup(req)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_invalidArgument), err)
+	}
+	previous, err := s.repo.IdTag.GetIdTagGroup(group.ParentIdTag)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get id tag group: %w", err))
+	}
+	previousAccess, err := s.repo.IdTag.GetIdTagAccess(group.ParentIdTag)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get id tag group access: %w", err))
+	}
+	access := newIdTagAccess(group.ParentIdTag, req.AllowedStationIds, req.AllowedLocationIds)
+	// Ограничения сохраняются первыми: станции получат их вместе с новой версией карт группы
+	if err := s.repo.IdTag.ReplaceIdTagAccess(group.ParentIdTag, access); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to save id tag group access: %w", err))
+	}
+	if err := s.repo.IdTag.SaveIdTagGroup(group); err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to save id tag group: %w", err))
+	}
+	// Карты группы могли быть закэшированы станциями как принятые под прежними ограничениями
+	go propagateIdTagGroup(s.repo, group.ParentIdTag, idTagGroupRestricted(previous, group, previousAccess, access))
+	return s.idTagGroupResponse(group, access)
+}
+
+func (s *CommandServiceServer) GetIdTagGroup(ctx context.Context, req *control.GetIdTagGroupRequest) (*control.IdTagGroupResponse, error) {
+	group, err := s.repo.IdTag.GetIdTagGroup(req.ParentIdTag)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get id tag group: %w", err))
+	}
+	if group == nil {
+		return nil, getCustomError(int64(control.ErrorCode_notFound), fmt.Errorf("Id tag group %s not found", req.ParentIdTag))
+	}
+	access, err := s.repo.IdTag.GetIdTagAccess(group.ParentIdTag)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get id tag group access: %w", err))
+	}
+	return s.idTagGroupResponse(group, access)
+}
+
+// idTagGroupResponse дополняет группу идущими транзакциями и энергией за текущий месяц
+func (s *CommandServiceServer) idTagGroupResponse(group *models.IdTagGroup, access []*models.IdTagAccess) (*control.IdTagGroupResponse, error) {
+	active, err := s.repo.Session.CountGroupActiveSessions(group.ParentIdTag)
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to count id tag group sessions: %w", err))
+	}
+	energy, err := s.repo.Session.GetGroupEnergy(group.ParentIdTag, monthStart(time.Now()))
+	if err != nil {
+		return nil, getCustomError(int64(control.ErrorCode_errorDB), fmt.Errorf("Failed to get id tag group energy: %w", err))
+	}
+	return &control.IdTagGroupResponse{
+		Group:          idTagGroupToProto(group, access),
+		ActiveSessions: int64(active),
+		MonthEnergyKwh: energy,
+	}, nil
+}
+
 func getCustomError(code int64, err error) error {
 	fmt.Println("Error:", err.Error())
 	customErrorDetail := &control.CustomErrorDetail{
@@ -61,8 +607,19 @@ func getCustomError(code int64, err error) error {
 		customErrorDetail.Error = err.Error()
 	}
 
+	details := []protoadapt.MessageV1{customErrorDetail}
+	// CALLERROR от станции передаём отдельной структурой, чтобы клиент видел причину отказа
+	var callErr *CallError
+	if errors.As(err, &callErr) {
+		details = append(details, &control.OcppErrorDetail{
+			ErrorCode:        callErr.ErrorCode,
+			ErrorDescription: callErr.ErrorDescription,
+			ErrorDetails:     string(callErr.ErrorDetails),
+		})
+	}
+
 	st := status.New(codes.InvalidArgument, "invalid parameter")
-	st, err = st.WithDetails(customErrorDetail)
+	st, err = st.WithDetails(details...)
 	fmt.Println(err)
 	fmt.Println(st)
 	return st.Err()
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+)
+
+type GetConfigurationRequest struct {
+	Key []string `json:"key,omitempty"`
+}
+
+type ConfigurationKeyValue struct {
+	Key      string  `json:"key"`
+	Readonly bool    `json:"readonly"`
+	Value    *string `json:"value,omitempty"`
+}
+
+type GetConfigurationResponse struct {
+	ConfigurationKey []ConfigurationKeyValue `json:"configurationKey,omitempty"`
+	UnknownKey       []string                `json:"unknownKey,omitempty"`
+}
+
+type ChangeConfigurationRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type ChangeConfigurationResponse struct {
+	Status string `json:"status"`
+}
+
+// requireOCPP16 проверяет, что команда есть в версии OCPP станции.
+// В 2.0.1 конфигурация устроена иначе (GetVariables/SetVariables по компонентам)
+func (s *StationService) requireOCPP16(command string) error {
+	if s.ocppVersion == models.OcppVersion201 {
+		return fmt.Errorf("%s is not supported for %s stations", command, s.ocppVersion)
+	}
+	return nil
+}
+
+// sendGetConfiguration запрашивает ключи конфигурации и обновляет сохранённый снимок.
+// Пустой keys - вся конфигурация, снимок станции заменяется целиком
+func (s *StationService) sendGetConfiguration(keys []string) (int, []*models.ConfigurationKey, []string, error) {
+	if err := s.requireOCPP16("GetConfiguration"); err != nil {
+		return int(control.ErrorCode_commandNotSupported), nil, nil, err
+	}
+
+	res := &GetConfigurationResponse{}
+	if err := s.sendRequest("GetConfiguration", GetConfigurationRequest{Key: keys}, res); err != nil {
+		return sendErrorCode(err), nil, nil, err
+	}
+	log.Printf("GetConfiguration ответ: ключей %d, неизвестных %d", len(res.ConfigurationKey), len(res.UnknownKey))
+
+	updatedAt := toDBTime(time.Now())
+	configuration := make([]*models.ConfigurationKey, 0, len(res.ConfigurationKey))
+	for _, kv := range res.ConfigurationKey {
+		key := &models.ConfigurationKey{
+			StationId: s.Station.Id,
+			Key:       kv.Key,
+			Readonly:  kv.Readonly,
+			UpdatedAt: updatedAt,
+		}
+		if kv.Value != nil {
+			key.Value = *kv.Value
+		}
+		configuration = append(configuration, key)
+	}
+
+	var err error
+	if len(keys) == 0 {
+		err = s.Repository.Configuration.ReplaceConfiguration(s.Station.Id, configuration)
+	} else {
+		err = s.Repository.Configuration.UpsertConfigurationKeys(configuration)
+	}
+	if err != nil {
+		log.Printf("Ошибка сохранения конфигурации станции %d: %v", s.Station.Id, err)
+	}
+	return 0, configuration, res.UnknownKey, nil
+}
+
+// sendChangeConfiguration меняет значение ключа. Принятое значение сразу попадает в снимок,
+// при RebootRequired - с отметкой, что оно вступит в силу после перезагрузки
+func (s *StationService) sendChangeConfiguration(key string, value string) (int, string, error) {
+	if err := s.requireOCPP16("ChangeConfiguration"); err != nil {
+		return int(control.ErrorCode_commandNotSupported), "", err
+	}
+
+	res := &ChangeConfigurationResponse{}
+	if err := s.sendRequest("ChangeConfiguration", ChangeConfigurationRequest{Key: key, Value: value}, res); err != nil {
+		return sendErrorCode(err), "", err
+	}
+	log.Printf("ChangeConfiguration %s=%s ответ: %+v", key, value, res)
+
+	switch res.Status {
+	case "Accepted", "RebootRequired":
+		err := s.Repository.Configuration.UpsertConfigurationKeys([]*models.ConfigurationKey{{
+			StationId:      s.Station.Id,
+			Key:            key,
+			Value:          value,
+			RebootRequired: res.Status == "RebootRequired",
+			UpdatedAt:      toDBTime(time.Now()),
+		}})
+		if err != nil {
+			log.Printf("Ошибка сохранения конфигурации станции %d: %v", s.Station.Id, err)
+		}
+		// Станция будет присылать Heartbeat с новым интервалом, сторож должен ждать столько же
+		if key == "HeartbeatInterval" && res.Status == "Accepted" {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+				s.setHeartbeatInterval(time.Duration(seconds) * time.Second)
+			}
+		}
+		return 0, res.Status, nil
+	case "NotSupported":
+		return int(control.ErrorCode_commandNotSupported), res.Status, fmt.Errorf("ChangeConfiguration key %s is not supported", key)
+	}
+	return int(control.ErrorCode_commandWasNotAccepted), res.Status, fmt.Errorf("ChangeConfiguration status: %s", res.Status)
+}
+
+// filterConfigurationKeys оставляет в снимке только запрошенные ключи (пустой names - все)
+func filterConfigurationKeys(keys []*models.ConfigurationKey, names []string) []*models.ConfigurationKey {
+	if len(names) == 0 {
+		return keys
+	}
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	var result []*models.ConfigurationKey
+	for _, k := range keys {
+		if wanted[k.Key] {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// configurationKeysToProto переводит снимок конфигурации в ответ gRPC
+func configurationKeysToProto(keys []*models.ConfigurationKey) []*control.ConfigurationKey {
+	result := make([]*control.ConfigurationKey, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, &control.ConfigurationKey{
+			Key:            k.Key,
+			Value:          k.Value,
+			Readonly:       k.Readonly,
+			RebootRequired: k.RebootRequired,
+			UpdatedAt:      k.UpdatedAt,
+		})
+	}
+	return result
+}
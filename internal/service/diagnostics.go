@@ -0,0 +1,205 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+)
+
+// diagnosticsChunkSize - размер части файла при скачивании диагностики через gRPC
+const diagnosticsChunkSize = 64 << 10
+
+type GetDiagnosticsRequest struct {
+	Location      string `json:"location"`
+	Retries       int    `json:"retries,omitempty"`
+	RetryInterval int    `json:"retryInterval,omitempty"`
+	StartTime     string `json:"startTime,omitempty"`
+	StopTime      string `json:"stopTime,omitempty"`
+}
+
+// GetDiagnosticsResponse - пустой fileName означает, что диагностики на станции нет
+type GetDiagnosticsResponse struct {
+	FileName string `json:"fileName,omitempty"`
+}
+
+// GetLogRequest201 - в OCPP 2.0.1 диагностика запрашивается через GetLog с logType DiagnosticsLog
+type GetLogRequest201 struct {
+	LogType       string `json:"logType"`
+	RequestId     int    `json:"requestId"`
+	Retries       int    `json:"retries,omitempty"`
+	RetryInterval int    `json:"retryInterval,omitempty"`
+	Log           struct {
+		RemoteLocation  string `json:"remoteLocation"`
+		OldestTimestamp string `json:"oldestTimestamp,omitempty"`
+		LatestTimestamp string `json:"latestTimestamp,omitempty"`
+	} `json:"log"`
+}
+
+type GetLogResponse201 struct {
+	Status   string `json:"status"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// diagnosticsStatuses переводит статусы DiagnosticsStatusNotification в статусы запроса диагностики
+var diagnosticsStatuses = map[string]string{
+	"Uploading":    models.DiagnosticsUploading,
+	"Uploaded":     models.DiagnosticsUploaded,
+	"UploadFailed": models.DiagnosticsUploadFailed,
+}
+
+// logStatuses201 переводит статусы LogStatusNotification в статусы запроса диагностики
+var logStatuses201 = map[string]string{
+	"Uploading":             models.DiagnosticsUploading,
+	"Uploaded":              models.DiagnosticsUploaded,
+	"UploadFailure":         models.DiagnosticsUploadFailed,
+	"BadMessage":            models.DiagnosticsUploadFailed,
+	"NotSupportedOperation": models.DiagnosticsUploadFailed,
+	"PermissionDenied":      models.DiagnosticsUploadFailed,
+}
+
+// sendGetDiagnostics отправляет станции запрос диагностики, записанный в request.
+// Имя файла из ответа станции сохраняется в запросе
+func (s *StationService) sendGetDiagnostics(request *models.DiagnosticsRequest) (int, error) {
+	startTime, stopTime := "", ""
+	if t, err := fromDBTime(request.StartTime); err == nil {
+		startTime = t.UTC().Format(time.RFC3339)
+	}
+	if t, err := fromDBTime(request.StopTime); err == nil {
+		stopTime = t.UTC().Format(time.RFC3339)
+	}
+
+	if s.ocppVersion == models.OcppVersion201 {
+		req := GetLogRequest201{LogType: "DiagnosticsLog", RequestId: request.Id, Retries: request.Retries, RetryInterval: request.RetryInterval}
+		req.Log.RemoteLocation = request.Location
+		req.Log.OldestTimestamp = startTime
+		req.Log.LatestTimestamp = stopTime
+		res := &GetLogResponse201{}
+		if err := s.sendRequest("GetLog", req, res); err != nil {
+			s.setDiagnosticsStatus(request, models.DiagnosticsFailed)
+			return sendErrorCode(err), err
+		}
+		log.Printf("GetLog ответ: %+v", res)
+		if res.Status != "Accepted" && res.Status != "AcceptedCanceled" {
+			s.setDiagnosticsStatus(request, models.DiagnosticsFailed)
+			return int(control.ErrorCode_commandWasNotAccepted), fmt.Errorf("GetLog status: %s", res.Status)
+		}
+		request.FileName = res.Filename
+		s.setDiagnosticsStatus(request, request.Status)
+		return 0, nil
+	}
+
+	req := GetDiagnosticsRequest{
+		Location:      request.Location,
+		Retries:       request.Retries,
+		RetryInterval: request.RetryInterval,
+		StartTime:     startTime,
+		StopTime:      stopTime,
+	}
+	res := &GetDiagnosticsResponse{}
+	if err := s.sendRequest("GetDiagnostics", req, res); err != nil {
+		s.setDiagnosticsStatus(request, models.DiagnosticsFailed)
+		return sendErrorCode(err), err
+	}
+	log.Printf("GetDiagnostics ответ: %+v", res)
+	request.FileName = res.FileName
+	status := request.Status
+	if res.FileName == "" {
+		status = models.DiagnosticsNoFile
+	}
+	s.setDiagnosticsStatus(request, status)
+	return 0, nil
+}
+
+// trackDiagnosticsStatus обновляет статус последнего незавершённого запроса диагностики станции
+func (s *StationService) trackDiagnosticsStatus(status string) {
+	mapped, ok := diagnosticsStatuses[status]
+	if s.Station == nil || !ok {
+		return
+	}
+	request, err := s.Repository.Diagnostics.GetActiveDiagnosticsRequest(s.Station.Id)
+	if err != nil || request == nil {
+		return
+	}
+	s.setDiagnosticsStatus(request, mapped)
+}
+
+// trackLogStatus201 обновляет запрос диагностики по LogStatusNotification: requestId - id запроса
+func (s *StationService) trackLogStatus201(status string, requestId int) {
+	mapped, ok := logStatuses201[status]
+	if !ok {
+		return
+	}
+	request, err := s.Repository.Diagnostics.GetDiagnosticsRequest(requestId)
+	if err != nil || request == nil || s.Station == nil || request.StationId != s.Station.Id {
+		return
+	}
+	s.setDiagnosticsStatus(request, mapped)
+}
+
+func (s *StationService) setDiagnosticsStatus(request *models.DiagnosticsRequest, status string) {
+	request.Status = status
+	request.UpdatedAt = toDBTime(time.Now())
+	if err := s.Repository.Diagnostics.UpdateDiagnosticsRequest(request); err != nil {
+		log.Printf("Ошибка обновления запроса диагностики %d: %v", request.Id, err)
+		return
+	}
+	log.Printf("Станция %s, запрос диагностики %d: %s", s.chargeBoxId(), request.Id, status)
+}
+
+// newDiagnosticsRequest проверяет параметры GetDiagnostics и готовит запись запроса
+func newDiagnosticsRequest(req *control.GetDiagnosticsRequest, location string) (*models.DiagnosticsRequest, error) {
+	now := toDBTime(time.Now())
+	request := &models.DiagnosticsRequest{
+		StationId:     int(req.StationId),
+		Location:      location,
+		Retries:       int(req.Retries),
+		RetryInterval: int(req.RetryInterval),
+		Status:        models.DiagnosticsRequested,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if req.StartTime != "" {
+		t, err := time.Parse(time.RFC3339, req.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		request.StartTime = toDBTime(t)
+	}
+	if req.StopTime != "" {
+		t, err := time.Parse(time.RFC3339, req.StopTime)
+		if err != nil {
+			return nil, err
+		}
+		request.StopTime = toDBTime(t)
+	}
+	return request, nil
+}
+
+func diagnosticsRequestToProto(d *models.DiagnosticsRequest) *control.DiagnosticsRequest {
+	return &control.DiagnosticsRequest{
+		Id:            int64(d.Id),
+		StationId:     int64(d.StationId),
+		Location:      d.Location,
+		StartTime:     d.StartTime,
+		StopTime:      d.StopTime,
+		Retries:       int64(d.Retries),
+		RetryInterval: int64(d.RetryInterval),
+		Status:        d.Status,
+		FileName:      d.FileName,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+func diagnosticsFileToProto(f *models.DiagnosticsFile) *control.DiagnosticsFile {
+	return &control.DiagnosticsFile{
+		Id:        int64(f.Id),
+		RequestId: int64(f.RequestId),
+		Name:      f.Name,
+		Size:      f.Size,
+		CreatedAt: f.CreatedAt,
+	}
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/files"
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+)
+
+const (
+	// firmwareCampaignInterval - как часто проверяется ход кампаний обновления прошивки
+	firmwareCampaignInterval = 30 * time.Second
+	// defaultFirmwareWaveSize - размер волны, если он не задан
+	defaultFirmwareWaveSize = 10
+	// defaultFirmwareStationTimeout - сколько станция может не менять состояние в кампании
+	defaultFirmwareStationTimeout = 2 * time.Hour
+)
+
+type UpdateFirmwareRequest struct {
+	Location      string `json:"location"`
+	RetrieveDate  string `json:"retrieveDate"`
+	Retries       int    `json:"retries,omitempty"`
+	RetryInterval int    `json:"retryInterval,omitempty"`
+}
+
+type UpdateFirmwareResponse struct{}
+
+type UpdateFirmwareRequest201 struct {
+	Retries       int `json:"retries,omitempty"`
+	RetryInterval int `json:"retryInterval,omitempty"`
+	RequestId     int `json:"requestId"`
+	Firmware      struct {
+		Location         string `json:"location"`
+		RetrieveDateTime string `json:"retrieveDateTime"`
+	} `json:"firmware"`
+}
+
+type UpdateFirmwareResponse201 struct {
+	Status string `json:"status"`
+}
+
+// sendUpdateFirmware отправляет станции UpdateFirmware. requestId передаётся только станциям 2.0.1,
+// они возвращают его в FirmwareStatusNotification
+func (s *StationService) sendUpdateFirmware(location string, retrieveDate time.Time, retries int, retryInterval int, requestId int) (int, error) {
+	if s.ocppVersion == models.OcppVersion201 {
+		req := UpdateFirmwareRequest201{Retries: retries, RetryInterval: retryInterval, RequestId: requestId}
+		req.Firmware.Location = location
+		req.Firmware.RetrieveDateTime = retrieveDate.UTC().Format(time.RFC3339)
+		res := &UpdateFirmwareResponse201{}
+		if err := s.sendRequest("UpdateFirmware", req, res); err != nil {
+			return sendErrorCode(err), err
+		}
+		log.Printf("UpdateFirmware ответ: %+v", res)
+		if res.Status != "Accepted" && res.Status != "AcceptedCanceled" {
+			return int(control.ErrorCode_commandWasNotAccepted), fmt.Errorf("UpdateFirmware status: %s", res.Status)
+		}
+		return 0, nil
+	}
+
+	req := UpdateFirmwareRequest{
+		Location:      location,
+		RetrieveDate:  retrieveDate.UTC().Format(time.RFC3339),
+		Retries:       retries,
+		RetryInterval: retryInterval,
+	}
+	// В 1.6J ответ на UpdateFirmware пустой, ход обновления виден только по FirmwareStatusNotification
+	if err := s.sendRequest("UpdateFirmware", req, &UpdateFirmwareResponse{}); err != nil {
+		return sendErrorCode(err), err
+	}
+	return 0, nil
+}
+
+// firmwareLocation возвращает адрес прошивки для станции. Внешний URL передаётся как есть,
+// имя файла встроенного сервера (<версия>/<файл>) превращается в подписанную ссылку для этой станции,
+// действующую до последней попытки скачивания
+func firmwareLocation(store *files.Store, stationId int, location string, retrieveDate time.Time, retries int, retryInterval int) (string, error) {
+	if strings.Contains(location, "://") {
+		return location, nil
+	}
+	return store.FirmwareURL(stationId, location, lastAttempt(retrieveDate, retries, retryInterval))
+}
+
+// lastAttempt возвращает, когда станция может начать последнюю из retries повторных попыток
+// скачивания или выгрузки файла, начатого в start
+func lastAttempt(start time.Time, retries int, retryInterval int) time.Time {
+	return start.Add(time.Duration(retries*retryInterval) * time.Second)
+}
+
+// trackFirmwareStatus переводит станцию в кампании по статусу FirmwareStatusNotification
+func (s *StationService) trackFirmwareStatus(status string) {
+	if s.Station == nil {
+		return
+	}
+	entry, err := s.Repository.Firmware.GetActiveFirmwareCampaignStation(s.Station.Id)
+	if err != nil || entry == nil {
+		return
+	}
+	switch status {
+	case models.FirmwareStationDownloading, models.FirmwareStationDownloaded, models.FirmwareStationInstalling:
+		entry.Status = status
+	case models.FirmwareStationInstalled:
+		// BootNotification с новой версией мог прийти раньше Installed
+		entry.Status = status
+		if campaign, err := s.Repository.Firmware.GetFirmwareCampaign(entry.CampaignId); err == nil && campaign != nil &&
+			s.Station.ChargeBoxFirmware == campaign.TargetVersion {
+			entry.Status = models.FirmwareStationVerified
+		}
+	case "DownloadFailed", "InstallationFailed", "InstallVerificationFailed", "InvalidSignature":
+		entry.Status = models.FirmwareStationFailed
+		entry.Error = status
+	default:
+		return
+	}
+	entry.UpdatedAt = toDBTime(time.Now())
+	if err := s.Repository.Firmware.UpdateFirmwareCampaignStation(entry); err != nil {
+		log.Printf("Ошибка обновления станции %d в кампании %d: %v", entry.StationId, entry.CampaignId, err)
+		return
+	}
+	log.Printf("Станция %s в кампании %d: %s", s.chargeBoxId(), entry.CampaignId, entry.Status)
+}
+
+// verifyFirmware после BootNotification проверяет, что станция загрузилась с целевой версией прошивки
+func (s *StationService) verifyFirmware() {
+	entry, err := s.Repository.Firmware.GetActiveFirmwareCampaignStation(s.Station.Id)
+	if err != nil || entry == nil {
+		return
+	}
+	campaign, err := s.Repository.Firmware.GetFirmwareCampaign(entry.CampaignId)
+	if err != nil || campaign == nil {
+		return
+	}
+	switch {
+	case s.Station.ChargeBoxFirmware == campaign.TargetVersion:
+		entry.Status = models.FirmwareStationVerified
+	case entry.Status == models.FirmwareStationInstalled:
+		entry.Status = models.FirmwareStationFailed
+		entry.Error = fmt.Sprintf("booted with firmware %s", s.Station.ChargeBoxFirmware)
+	default:
+		// Перезагрузка до установки, ждём дальше
+		return
+	}
+	entry.UpdatedAt = toDBTime(time.Now())
+	if err := s.Repository.Firmware.UpdateFirmwareCampaignStation(entry); err != nil {
+		log.Printf("Ошибка обновления станции %d в кампании %d: %v", entry.StationId, entry.CampaignId, err)
+		return
+	}
+	log.Printf("Станция %s в кампании %d после загрузки с прошивкой %s: %s", s.chargeBoxId(), entry.CampaignId, s.Station.ChargeBoxFirmware, entry.Status)
+}
+
+// FirmwareCampaignRunner ведёт кампании обновления прошивки: отправляет UpdateFirmware станциям
+// текущей волны, переходит к следующей волне и ставит кампанию на паузу при превышении порога ошибок
+type FirmwareCampaignRunner struct {
+	repo  *repository.Repository
+	files *files.Store
+}
+
+func NewFirmwareCampaignRunner(repo *repository.Repository, files *files.Store) *FirmwareCampaignRunner {
+	return &FirmwareCampaignRunner{repo: repo, files: files}
+}
+
+// Run обрабатывает активные кампании до завершения процесса
+func (r *FirmwareCampaignRunner) Run() {
+	ticker := time.NewTicker(firmwareCampaignInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		campaigns, err := r.repo.Firmware.GetFirmwareCampaignsByStatus(models.FirmwareCampaignRunning)
+		if err != nil {
+			log.Printf("Ошибка получения кампаний обновления прошивки: %v", err)
+			continue
+		}
+		for _, campaign := range campaigns {
+			if err := r.process(campaign); err != nil {
+				log.Printf("Ошибка обработки кампании %d: %v", campaign.Id, err)
+			}
+		}
+	}
+}
+
+func (r *FirmwareCampaignRunner) process(campaign *models.FirmwareCampaign) error {
+	stations, err := r.repo.Firmware.GetFirmwareCampaignStations(campaign.Id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	// Станции, которые слишком долго не меняют состояние, считаются неудачными
+	for _, entry := range stations {
+		if entry.Wave != campaign.CurrentWave || entry.Finished() {
+			continue
+		}
+		updatedAt, err := fromDBTime(entry.UpdatedAt)
+		if err != nil || now.Sub(updatedAt) < time.Duration(campaign.StationTimeout)*time.Second {
+			continue
+		}
+		entry.Status = models.FirmwareStationFailed
+		entry.Error = "timeout"
+		entry.UpdatedAt = toDBTime(now)
+		if err := r.repo.Firmware.UpdateFirmwareCampaignStation(entry); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	waveFinished := true
+	lastWave := 0
+	for _, entry := range stations {
+		if entry.Status == models.FirmwareStationFailed {
+			failed++
+		}
+		if entry.Wave == campaign.CurrentWave && !entry.Finished() {
+			waveFinished = false
+		}
+		if entry.Wave > lastWave {
+			lastWave = entry.Wave
+		}
+	}
+
+	if failed-campaign.ResumedFailures > campaign.FailureThreshold {
+		log.Printf("Кампания %d: ошибок %d (после возобновления %d) при пороге %d, пауза",
+			campaign.Id, failed, failed-campaign.ResumedFailures, campaign.FailureThreshold)
+		return r.setCampaignStatus(campaign, models.FirmwareCampaignPaused)
+	}
+	if waveFinished {
+		if campaign.CurrentWave >= lastWave {
+			log.Printf("Кампания %d завершена, ошибок: %d", campaign.Id, failed)
+			return r.setCampaignStatus(campaign, models.FirmwareCampaignCompleted)
+		}
+		campaign.CurrentWave++
+		log.Printf("Кампания %d: переход к волне %d", campaign.Id, campaign.CurrentWave)
+		if err := r.setCampaignStatus(campaign, campaign.Status); err != nil {
+			return err
+		}
+		// StationTimeout новой волны отсчитывается от её начала
+		for _, entry := range stations {
+			if entry.Wave != campaign.CurrentWave {
+				continue
+			}
+			entry.UpdatedAt = toDBTime(now)
+			if err := r.repo.Firmware.UpdateFirmwareCampaignStation(entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, entry := range stations {
+		if entry.Wave == campaign.CurrentWave && entry.Status == models.FirmwareStationPending {
+			r.startStation(campaign, entry)
+		}
+	}
+	return nil
+}
+
+// startStation отправляет UpdateFirmware подключённой станции. Отключённые станции ждут
+// подключения до истечения StationTimeout
+func (r *FirmwareCampaignRunner) startStation(campaign *models.FirmwareCampaign, entry *models.FirmwareCampaignStation) {
+	service, ok := GetStationService(entry.StationId)
+	if !ok {
+		return
+	}
+	retrieveDate := time.Now()
+	if campaign.RetrieveDate != "" {
+		if t, err := fromDBTime(campaign.RetrieveDate); err == nil && t.After(retrieveDate) {
+			retrieveDate = t
+		}
+	}
+	location, err := firmwareLocation(r.files, entry.StationId, campaign.FirmwareUrl, retrieveDate, 0, 0)
+	if err != nil {
+		log.Printf("Кампания %d: %v", campaign.Id, err)
+		return
+	}
+	// Отмечаем отправку до ответа станции, чтобы следующий проход не отправил команду повторно
+	entry.Status = models.FirmwareStationSent
+	entry.UpdatedAt = toDBTime(time.Now())
+	if err := r.repo.Firmware.UpdateFirmwareCampaignStation(entry); err != nil {
+		log.Printf("Ошибка обновления станции %d в кампании %d: %v", entry.StationId, entry.CampaignId, err)
+		return
+	}
+	go func() {
+		if _, err := service.sendUpdateFirmware(location, retrieveDate, 0, 0, campaign.Id); err != nil {
+			log.Printf("Кампания %d: станция %d не приняла UpdateFirmware: %v", campaign.Id, entry.StationId, err)
+			entry.Status = models.FirmwareStationFailed
+			entry.Error = err.Error()
+			entry.UpdatedAt = toDBTime(time.Now())
+			if err := r.repo.Firmware.UpdateFirmwareCampaignStation(entry); err != nil {
+				log.Printf("Ошибка обновления станции %d в кампании %d: %v", entry.StationId, entry.CampaignId, err)
+			}
+		}
+	}()
+}
+
+// countFailedStations возвращает число станций кампании, завершившихся ошибкой
+func countFailedStations(stations []*models.FirmwareCampaignStation) int {
+	failed := 0
+	for _, entry := range stations {
+		if entry.Status == models.FirmwareStationFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+func (r *FirmwareCampaignRunner) setCampaignStatus(campaign *models.FirmwareCampaign, status string) error {
+	campaign.Status = status
+	campaign.UpdatedAt = toDBTime(time.Now())
+	return r.repo.Firmware.UpdateFirmwareCampaign(campaign)
+}
+
+// newFirmwareCampaign разбивает станции на волны и заполняет значения по умолчанию
+func newFirmwareCampaign(req *control.CreateFirmwareCampaignRequest) (*models.FirmwareCampaign, []*models.FirmwareCampaignStation, error) {
+	if req.FirmwareUrl == "" || req.TargetVersion == "" || len(req.StationIds) == 0 {
+		return nil, nil, fmt.Errorf("firmware_url, target_version and station_ids are required")
+	}
+	now := toDBTime(time.Now())
+	campaign := &models.FirmwareCampaign{
+		FirmwareUrl:      req.FirmwareUrl,
+		TargetVersion:    req.TargetVersion,
+		WaveSize:         int(req.WaveSize),
+		FailureThreshold: int(req.FailureThreshold),
+		StationTimeout:   int(req.StationTimeoutSeconds),
+		Status:           models.FirmwareCampaignRunning,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if campaign.WaveSize <= 0 {
+		campaign.WaveSize = defaultFirmwareWaveSize
+	}
+	if campaign.StationTimeout <= 0 {
+		campaign.StationTimeout = int(defaultFirmwareStationTimeout.Seconds())
+	}
+	if req.RetrieveDate != "" {
+		retrieveDate, err := time.Parse(time.RFC3339, req.RetrieveDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid retrieve_date: %w", err)
+		}
+		campaign.RetrieveDate = toDBTime(retrieveDate)
+	}
+
+	stations := make([]*models.FirmwareCampaignStation, 0, len(req.StationIds))
+	for i, stationId := range req.StationIds {
+		stations = append(stations, &models.FirmwareCampaignStation{
+			StationId: int(stationId),
+			Wave:      i / campaign.WaveSize,
+			Status:    models.FirmwareStationPending,
+			UpdatedAt: now,
+		})
+	}
+	return campaign, stations, nil
+}
+
+// firmwareCampaignToProto переводит кампанию и её станции в ответ gRPC
+func firmwareCampaignToProto(campaign *models.FirmwareCampaign, stations []*models.FirmwareCampaignStation) *control.FirmwareCampaignResponse {
+	res := &control.FirmwareCampaignResponse{
+		Campaign: &control.FirmwareCampaign{
+			Id:                    int64(campaign.Id),
+			FirmwareUrl:           campaign.FirmwareUrl,
+			TargetVersion:         campaign.TargetVersion,
+			RetrieveDate:          campaign.RetrieveDate,
+			WaveSize:              int64(campaign.WaveSize),
+			CurrentWave:           int64(campaign.CurrentWave),
+			FailureThreshold:      int64(campaign.FailureThreshold),
+			ResumedFailures:       int64(campaign.ResumedFailures),
+			StationTimeoutSeconds: int64(campaign.StationTimeout),
+			Status:                campaign.Status,
+			CreatedAt:             campaign.CreatedAt,
+			UpdatedAt:             campaign.UpdatedAt,
+		},
+	}
+	for _, entry := range stations {
+		res.Stations = append(res.Stations, &control.FirmwareCampaignStation{
+			StationId: int64(entry.StationId),
+			Wave:      int64(entry.Wave),
+			Status:    entry.Status,
+			Error:     entry.Error,
+			UpdatedAt: entry.UpdatedAt,
+		})
+	}
+	return res
+}
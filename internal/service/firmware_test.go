@@ -0,0 +1,230 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+)
+
+func TestNewFirmwareCampaign(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *control.CreateFirmwareCampaignRequest
+		wantErr     bool
+		wantWaves   []int
+		wantSize    int
+		wantTimeout int
+	}{
+		{
+			name:    "no stations",
+			req:     &control.CreateFirmwareCampaignRequest{FirmwareUrl: "fw.bin", TargetVersion: "2.0"},
+			wantErr: true,
+		},
+		{
+			name:    "no target version",
+			req:     &control.CreateFirmwareCampaignRequest{FirmwareUrl: "fw.bin", StationIds: []int64{1}},
+			wantErr: true,
+		},
+		{
+			name: "invalid retrieve date",
+			req: &control.CreateFirmwareCampaignRequest{FirmwareUrl: "fw.bin", TargetVersion: "2.0",
+				StationIds: []int64{1}, RetrieveDate: "tomorrow"},
+			wantErr: true,
+		},
+		{
+			name: "waves of two",
+			req: &control.CreateFirmwareCampaignRequest{FirmwareUrl: "fw.bin", TargetVersion: "2.0",
+				StationIds: []int64{1, 2, 3, 4, 5}, WaveSize: 2, StationTimeoutSeconds: 600},
+			wantWaves:   []int{0, 0, 1, 1, 2},
+			wantSize:    2,
+			wantTimeout: 600,
+		},
+		{
+			name: "defaults",
+			req: &control.CreateFirmwareCampaignRequest{FirmwareUrl: "fw.bin", TargetVersion: "2.0",
+				StationIds: []int64{1, 2, 3}},
+			wantWaves:   []int{0, 0, 0},
+			wantSize:    defaultFirmwareWaveSize,
+			wantTimeout: int(defaultFirmwareStationTimeout.Seconds()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaign, stations, err := newFirmwareCampaign(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newFirmwareCampaign() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if campaign.WaveSize != tt.wantSize || campaign.StationTimeout != tt.wantTimeout ||
+				campaign.Status != models.FirmwareCampaignRunning || campaign.CurrentWave != 0 {
+				t.Errorf("newFirmwareCampaign() campaign = %+v", campaign)
+			}
+			if len(stations) != len(tt.wantWaves) {
+				t.Fatalf("newFirmwareCampaign() returned %d stations, want %d", len(stations), len(tt.wantWaves))
+			}
+			for i, entry := range stations {
+				if entry.StationId != int(tt.req.StationIds[i]) || entry.Wave != tt.wantWaves[i] ||
+					entry.Status != models.FirmwareStationPending {
+					t.Errorf("station %d = %+v, want wave %d", i, entry, tt.wantWaves[i])
+				}
+			}
+		})
+	}
+}
+
+// fakeFirmware хранит станции одной кампании и сохранённые изменения
+type fakeFirmware struct {
+	repository.Firmware
+	stations []*models.FirmwareCampaignStation
+	saved    *models.FirmwareCampaign
+}
+
+func (f *fakeFirmware) GetFirmwareCampaignStations(campaignId int) ([]*models.FirmwareCampaignStation, error) {
+	return f.stations, nil
+}
+
+func (f *fakeFirmware) UpdateFirmwareCampaignStation(s *models.FirmwareCampaignStation) error {
+	return nil
+}
+
+func (f *fakeFirmware) UpdateFirmwareCampaign(c *models.FirmwareCampaign) error {
+	saved := *c
+	f.saved = &saved
+	return nil
+}
+
+func TestFirmwareCampaignProcess(t *testing.T) {
+	// Станции кампании не подключены, поэтому process не отправляет им UpdateFirmware
+	fresh := toDBTime(time.Now())
+	stale := toDBTime(time.Now().Add(-2 * time.Hour))
+	entry := func(stationId int, wave int, status string, updatedAt string) *models.FirmwareCampaignStation {
+		return &models.FirmwareCampaignStation{CampaignId: 1, StationId: 9000 + stationId, Wave: wave, Status: status, UpdatedAt: updatedAt}
+	}
+	tests := []struct {
+		name            string
+		currentWave     int
+		threshold       int
+		resumedFailures int
+		stations        []*models.FirmwareCampaignStation
+		wantStatus      string
+		wantWave        int
+		wantFailed      []int
+	}{
+		{
+			name: "wave in progress",
+			stations: []*models.FirmwareCampaignStation{
+				entry(1, 0, models.FirmwareStationVerified, fresh),
+				entry(2, 0, models.FirmwareStationDownloading, fresh),
+				entry(3, 1, models.FirmwareStationPending, fresh),
+			},
+			wantStatus: models.FirmwareCampaignRunning,
+		},
+		{
+			name: "next wave",
+			stations: []*models.FirmwareCampaignStation{
+				entry(1, 0, models.FirmwareStationVerified, fresh),
+				entry(2, 0, models.FirmwareStationVerified, fresh),
+				entry(3, 1, models.FirmwareStationPending, stale),
+			},
+			wantStatus: models.FirmwareCampaignRunning,
+			wantWave:   1,
+		},
+		{
+			name:        "last wave finished",
+			currentWave: 1,
+			threshold:   1,
+			stations: []*models.FirmwareCampaignStation{
+				entry(1, 0, models.FirmwareStationVerified, fresh),
+				entry(2, 1, models.FirmwareStationFailed, fresh),
+			},
+			wantStatus: models.FirmwareCampaignCompleted,
+			wantWave:   1,
+			wantFailed: []int{2},
+		},
+		{
+			name: "stale station times out",
+			stations: []*models.FirmwareCampaignStation{
+				entry(1, 0, models.FirmwareStationSent, stale),
+				entry(2, 0, models.FirmwareStationDownloading, fresh),
+			},
+			threshold:  1,
+			wantStatus: models.FirmwareCampaignRunning,
+			wantFailed: []int{1},
+		},
+		{
+			name: "failures over threshold pause",
+			stations: []*models.FirmwareCampaignStation{
+				entry(1, 0, models.FirmwareStationFailed, fresh),
+				entry(2, 0, models.FirmwareStationSent, stale),
+				entry(3, 0, models.FirmwareStationDownloading, fresh),
+			},
+			threshold:  1,
+			wantStatus: models.FirmwareCampaignPaused,
+			wantFailed: []int{1, 2},
+		},
+		{
+			name: "failures before resume are not counted",
+			stations: []*models.FirmwareCampaignStation{
+				entry(1, 0, models.FirmwareStationFailed, fresh),
+				entry(2, 0, models.FirmwareStationFailed, fresh),
+				entry(3, 0, models.FirmwareStationDownloading, fresh),
+			},
+			resumedFailures: 2,
+			wantStatus:      models.FirmwareCampaignRunning,
+			wantFailed:      []int{1, 2},
+		},
+		{
+			name: "new failure after resume pauses again",
+			stations: []*models.FirmwareCampaignStation{
+				entry(1, 0, models.FirmwareStationFailed, fresh),
+				entry(2, 0, models.FirmwareStationFailed, fresh),
+				entry(3, 0, models.FirmwareStationSent, stale),
+			},
+			resumedFailures: 2,
+			wantStatus:      models.FirmwareCampaignPaused,
+			wantFailed:      []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firmware := &fakeFirmware{stations: tt.stations}
+			r := NewFirmwareCampaignRunner(&repository.Repository{Firmware: firmware}, nil)
+			campaign := &models.FirmwareCampaign{
+				Id:               1,
+				CurrentWave:      tt.currentWave,
+				FailureThreshold: tt.threshold,
+				ResumedFailures:  tt.resumedFailures,
+				StationTimeout:   3600,
+				Status:           models.FirmwareCampaignRunning,
+			}
+			if err := r.process(campaign); err != nil {
+				t.Fatal(err)
+			}
+			if campaign.Status != tt.wantStatus || campaign.CurrentWave != tt.wantWave {
+				t.Errorf("campaign status = %s, wave = %d, want %s, %d", campaign.Status, campaign.CurrentWave, tt.wantStatus, tt.wantWave)
+			}
+			if campaign.Status != models.FirmwareCampaignRunning || campaign.CurrentWave != tt.currentWave {
+				if firmware.saved == nil || firmware.saved.Status != campaign.Status {
+					t.Errorf("campaign change was not saved")
+				}
+			}
+			failed := make(map[int]bool)
+			for _, stationId := range tt.wantFailed {
+				failed[9000+stationId] = true
+			}
+			for _, entry := range tt.stations {
+				if (entry.Status == models.FirmwareStationFailed) != failed[entry.StationId] {
+					t.Errorf("station %d status = %s", entry.StationId, entry.Status)
+				}
+				if entry.Wave == campaign.CurrentWave && entry.UpdatedAt == stale && entry.Status == models.FirmwareStationPending {
+					t.Errorf("station %d of the new wave kept its old timestamp", entry.StationId)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// touch продлевает срок жизни соединения: любое сообщение или pong от станции
+// должно приходить не реже, чем раз в два интервала ping
+func (s *StationService) touch() {
+	if err := s.conn.SetReadDeadline(time.Now().Add(2 * s.pingInterval)); err != nil {
+		log.Printf("Станция %s: ошибка установки дедлайна чтения: %v", s.chargeBoxId(), err)
+	}
+}
+
+// touchMessage отмечает получение OCPP-сообщения
+func (s *StationService) touchMessage() {
+	s.lastMessage.Store(time.Now().UnixNano())
+	s.touch()
+}
+
+// startKeepalive включает обработку pong и сторож OCPP Heartbeat
+func (s *StationService) startKeepalive() {
+	s.conn.SetPongHandler(func(string) error {
+		s.touch()
+		return nil
+	})
+	s.touchMessage()
+	go s.heartbeatWatchdog()
+}
+
+func (s *StationService) getHeartbeatInterval() time.Duration {
+	return time.Duration(s.heartbeatInterval.Load())
+}
+
+// setHeartbeatInterval меняет интервал Heartbeat, принятый станцией, и перезапускает сторож
+func (s *StationService) setHeartbeatInterval(interval time.Duration) {
+	s.heartbeatInterval.Store(int64(interval))
+	select {
+	case s.heartbeatChanged <- struct{}{}:
+	default:
+	}
+}
+
+// heartbeatWatchdog закрывает соединение, если станция не присылала OCPP-сообщений
+// дольше MissedHeartbeats интервалов Heartbeat (pong сюда не считается)
+func (s *StationService) heartbeatWatchdog() {
+	interval := s.getHeartbeatInterval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			last := time.Unix(0, s.lastMessage.Load())
+			if time.Since(last) > interval*time.Duration(s.missedHeartbeats) {
+				log.Printf("Станция %s: нет сообщений с %s, закрываем соединение", s.chargeBoxId(), last.Format(time.RFC3339))
+				s.Close(websocket.CloseGoingAway, errHeartbeatTimeout)
+				return
+			}
+		case <-s.heartbeatChanged:
+			interval = s.getHeartbeatInterval()
+			ticker.Reset(interval)
+			log.Printf("Станция %s: сторож Heartbeat перезапущен с интервалом %v", s.chargeBoxId(), interval)
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+)
+
+// defaultLocalListChunkSize - сколько idTag отправляется в одном SendLocalList,
+// если станция не сообщила SendLocalListMaxLength
+const defaultLocalListChunkSize = 100
+
+type IdTagInfo struct {
+	Status      string `json:"status"`
+	ExpiryDate  string `json:"expiryDate,omitempty"`
+	ParentIdTag string `json:"parentIdTag,omitempty"`
+}
+
+// AuthorizationData - запись локального списка. Без idTagInfo запись удаляется из списка станции
+type AuthorizationData struct {
+	IdTag     string     `json:"idTag"`
+	IdTagInfo *IdTagInfo `json:"idTagInfo,omitempty"`
+}
+
+type SendLocalListRequest struct {
+	ListVersion            int                 `json:"listVersion"`
+	LocalAuthorizationList []AuthorizationData `json:"localAuthorizationList,omitempty"`
+	UpdateType             string              `json:"updateType"`
+}
+
+type SendLocalListResponse struct {
+	Status string `json:"status"`
+}
+
+type GetLocalListVersionRequest struct{}
+
+// GetLocalListVersionResponse - listVersion = -1 означает, что локальный список на станции выключен
+type GetLocalListVersionResponse struct {
+	ListVersion int `json:"listVersion"`
+}
+
+type AuthorizationData201 struct {
+	IdToken     IdToken201      `json:"idToken"`
+	IdTokenInfo *IdTokenInfo201 `json:"idTokenInfo,omitempty"`
+}
+
+type SendLocalListRequest201 struct {
+	VersionNumber          int                    `json:"versionNumber"`
+	UpdateType             string                 `json:"updateType"`
+	LocalAuthorizationList []AuthorizationData201 `json:"localAuthorizationList,omitempty"`
+}
+
+type GetLocalListVersionResponse201 struct {
+	VersionNumber int `json:"versionNumber"`
+}
+
+// idTagValid сообщает, что idTag принят и срок его действия не истёк
+func idTagValid(tag *models.IdTag, now time.Time) bool {
+	if tag.Status != models.IdTagAccepted {
+		return false
+	}
+	if expiry, err := fromDBTime(tag.ExpiryDate); err == nil && !expiry.After(now) {
+		return false
+	}
+	return true
+}
+
+// idTagExpiryDate возвращает срок действия idTag в RFC3339, пусто - бессрочно
+func idTagExpiryDate(tag *models.IdTag) string {
+	if expiry, err := fromDBTime(tag.ExpiryDate); err == nil {
+		return expiry.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+// sendGetLocalListVersion запрашивает версию локального списка станции
+func (s *StationService) sendGetLocalListVersion() (int, int, error) {
+	if s.ocppVersion == models.OcppVersion201 {
+		res := &GetLocalListVersionResponse201{}
+		if err := s.sendRequest("GetLocalListVersion", GetLocalListVersionRequest{}, res); err != nil {
+			return sendErrorCode(err), 0, err
+		}
+		log.Printf("GetLocalListVersion ответ: %+v", res)
+		return 0, res.VersionNumber, nil
+	}
+	res := &GetLocalListVersionResponse{}
+	if err := s.sendRequest("GetLocalListVersion", GetLocalListVersionRequest{}, res); err != nil {
+		return sendErrorCode(err), 0, err
+	}
+	log.Printf("GetLocalListVersion ответ: %+v", res)
+	return 0, res.ListVersion, nil
+}
+
+// sendLocalList отправляет станции одну часть локального списка. idTag из restricted и карты
+// групп из restricted не разрешены на станции и удаляются из её списка
+func (s *StationService) sendLocalList(version int, updateType string, tags []*models.IdTag, restricted map[string]bool) (string, error) {
+	now := time.Now()
+	var req interface{}
+	if s.ocppVersion == models.OcppVersion201 {
+		req201 := SendLocalListRequest201{VersionNumber: version, UpdateType: updateType}
+		for _, tag := range tags {
+			entry := AuthorizationData201{IdToken: IdToken201{IdToken: tag.IdTag, Type: "ISO14443"}}
+			if idTagValid(tag, now) && !localListRestricted(tag, restricted) {
+				entry.IdTokenInfo = &IdTokenInfo201{Status: tag.Status, CacheExpiryDateTime: idTagExpiryDate(tag)}
+				if tag.ParentIdTag != "" {
+					entry.IdTokenInfo.GroupIdToken = &IdToken201{IdToken: tag.ParentIdTag, Type: "Central"}
+				}
+			}
+			req201.LocalAuthorizationList = append(req201.LocalAuthorizationList, entry)
+		}
+		req = req201
+	} else {
+		req16 := SendLocalListRequest{ListVersion: version, UpdateType: updateType}
+		for _, tag := range tags {
+			// В OCPP 1.6 idTag не длиннее 20 символов, такие карты станции 1.6 не передаются
+			if len(tag.IdTag) > 20 {
+				continue
+			}
+			entry := AuthorizationData{IdTag: tag.IdTag}
+			if idTagValid(tag, now) && !localListRestricted(tag, restricted) {
+				entry.IdTagInfo = &IdTagInfo{Status: tag.Status, ExpiryDate: idTagExpiryDate(tag), ParentIdTag: tag.ParentIdTag}
+			}
+			req16.LocalAuthorizationList = append(req16.LocalAuthorizationList, entry)
+		}
+		req = req16
+	}
+
+	res := &SendLocalListResponse{}
+	if err := s.sendRequest("SendLocalList", req, res); err != nil {
+		return "", err
+	}
+	log.Printf("SendLocalList ответ: %+v", res)
+	return res.Status, nil
+}
+
+// localListChunkSize возвращает SendLocalListMaxLength из последней известной конфигурации станции
+func (s *StationService) localListChunkSize() int {
+	keys, err := s.Repository.Configuration.GetConfigurationByStationID(s.Station.Id)
+	if err != nil {
+		return defaultLocalListChunkSize
+	}
+	for _, key := range keys {
+		if key.Key != "SendLocalListMaxLength" {
+			continue
+		}
+		if n, err := strconv.Atoi(key.Value); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultLocalListChunkSize
+}
+
+// syncLocalList приводит локальный список станции к текущей версии. Изменения отправляются,
+// только если станция хранит последнюю отправленную ей версию, иначе и при VersionMismatch - весь список.
+// Возвращает версию списка на станции
+func (s *StationService) syncLocalList(full bool) (int, int, error) {
+	s.localListMu.Lock()
+	defer s.localListMu.Unlock()
+
+	listVersion, err := s.Repository.IdTag.GetIdTagListVersion()
+	if err != nil {
+		return int(control.ErrorCode_errorDB), 0, err
+	}
+	synced, err := s.Repository.IdTag.GetLocalListVersion(s.Station.Id)
+	if err != nil {
+		return int(control.ErrorCode_errorDB), 0, err
+	}
+	code, stationVersion, err := s.sendGetLocalListVersion()
+	if code != 0 {
+		return code, 0, err
+	}
+	if stationVersion < 0 {
+		return int(control.ErrorCode_commandNotSupported), stationVersion, fmt.Errorf("local authorization list is disabled")
+	}
+	if listVersion == 0 || (!full && stationVersion == listVersion && synced == listVersion) {
+		return 0, stationVersion, nil
+	}
+	if stationVersion == 0 || stationVersion != synced {
+		full = true
+	}
+
+	status, code, err := s.pushLocalList(listVersion, synced, full)
+	if status == "VersionMismatch" && !full {
+		log.Printf("Станция %s: VersionMismatch при обновлении списка до версии %d, отправляем список целиком", s.chargeBoxId(), listVersion)
+		_, code, err = s.pushLocalList(listVersion, synced, true)
+	}
+	if code != 0 {
+		return code, stationVersion, err
+	}
+	if err := s.Repository.IdTag.SetLocalListVersion(s.Station.Id, listVersion, toDBTime(time.Now())); err != nil {
+		return int(control.ErrorCode_errorDB), listVersion, err
+	}
+	log.Printf("Станция %s: локальный список обновлён с версии %d до %d", s.chargeBoxId(), stationVersion, listVersion)
+	return 0, listVersion, nil
+}
+
+// pushLocalList отправляет список частями по localListChunkSize: первая часть полного списка
+// заменяет список станции, остальные дополняют его
+func (s *StationService) pushLocalList(listVersion int, synced int, full bool) (string, int, error) {
+	var tags []*models.IdTag
+	var err error
+	updateType := "Differential"
+	if full {
+		updateType = "Full"
+		tags, err = s.Repository.IdTag.GetValidIdTags(toDBTime(time.Now()))
+	} else {
+		tags, err = s.Repository.IdTag.GetIdTagsChangedSince(synced)
+	}
+	if err != nil {
+		return "", int(control.ErrorCode_errorDB), err
+	}
+	restricted, err := s.restrictedIdTags(tags)
+	if err != nil {
+		return "", int(control.ErrorCode_errorDB), err
+	}
+	if full {
+		allowed := tags[:0]
+		for _, tag := range tags {
+			if !localListRestricted(tag, restricted) {
+				allowed = append(allowed, tag)
+			}
+		}
+		tags = allowed
+	}
+
+	chunkSize := s.localListChunkSize()
+	for start := 0; start == 0 || start < len(tags); start += chunkSize {
+		end := start + chunkSize
+		if end > len(tags) {
+			end = len(tags)
+		}
+		status, err := s.sendLocalList(listVersion, updateType, tags[start:end], restricted)
+		if err != nil {
+			return "", sendErrorCode(err), err
+		}
+		switch status {
+		case "Accepted":
+		case "NotSupported":
+			return status, int(control.ErrorCode_commandNotSupported), fmt.Errorf("SendLocalList status: %s", status)
+		default:
+			return status, int(control.ErrorCode_commandWasNotAccepted), fmt.Errorf("SendLocalList status: %s", status)
+		}
+		updateType = "Differential"
+	}
+	return "Accepted", 0, nil
+}
+
+// restrictedIdTags возвращает idTag, ограничения которых не разрешают эту станцию, и группы tags,
+// чей parentIdTag не действует: карты таких групп станция должна проверять у сервера
+func (s *StationService) restrictedIdTags(tags []*models.IdTag) (map[string]bool, error) {
+	restricted := make(map[string]bool)
+	now := time.Now()
+	idTags := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		idTags = append(idTags, tag.IdTag)
+		if tag.ParentIdTag == "" {
+			continue
+		}
+		if _, checked := restricted[tag.ParentIdTag]; checked {
+			continue
+		}
+		parent, err := s.Repository.IdTag.GetIdTag(tag.ParentIdTag)
+		if err != nil {
+			return nil, err
+		}
+		restricted[tag.ParentIdTag] = parent != nil && (parent.Status != models.IdTagAccepted || !idTagValid(parent, now))
+		idTags = append(idTags, tag.ParentIdTag)
+	}
+
+	access, err := s.Repository.IdTag.GetIdTagsAccess(idTags)
+	if err != nil || len(access) == 0 {
+		return restricted, err
+	}
+	locationId, err := s.Repository.Station.GetLocationID(s.Station.Id)
+	if err != nil {
+		return nil, err
+	}
+	byIdTag := make(map[string][]*models.IdTagAccess)
+	for _, a := range access {
+		byIdTag[a.IdTag] = append(byIdTag[a.IdTag], a)
+	}
+	for idTag, tagAccess := range byIdTag {
+		if !idTagAllowedAt(tagAccess, s.Station.Id, locationId) {
+			restricted[idTag] = true
+		}
+	}
+	return restricted, nil
+}
+
+// localListRestricted сообщает, что станция не разрешена idTag или его группе
+func localListRestricted(tag *models.IdTag, restricted map[string]bool) bool {
+	return restricted[tag.IdTag] || (tag.ParentIdTag != "" && restricted[tag.ParentIdTag])
+}
+
+// syncLocalListOnConnect обновляет локальный список после подключения: пока станция была
+// без связи, idTag могли измениться
+func (s *StationService) syncLocalListOnConnect() {
+	if s.Station == nil {
+		return
+	}
+	if _, _, err := s.syncLocalList(false); err != nil {
+		log.Printf("Станция %s: не удалось обновить локальный список после подключения: %v", s.chargeBoxId(), err)
+	}
+}
+
+// syncConnectedLocalLists рассылает изменения локального списка всем подключённым станциям
+func syncConnectedLocalLists() {
+	for _, service := range ConnectedStationServices() {
+		if service.Station == nil {
+			continue
+		}
+		if _, _, err := service.syncLocalList(false); err != nil {
+			log.Printf("Станция %s: не удалось обновить локальный список: %v", service.chargeBoxId(), err)
+		}
+	}
+}
+
+// newIdTag проверяет параметры SetIdTag и готовит запись idTag
+func newIdTag(req *control.SetIdTagRequest) (*models.IdTag, error) {
+	if req.IdTag == "" || len(req.IdTag) > 36 {
+		return nil, fmt.Errorf("id_tag is required and must not be longer than 36 characters")
+	}
+	status := req.Status
+	if status == "" {
+		status = models.IdTagAccepted
+	}
+	switch status {
+	case models.IdTagAccepted, models.IdTagBlocked, models.IdTagExpired, models.IdTagInvalid:
+	default:
+		return nil, fmt.Errorf("unknown status %s", req.Status)
+	}
+	tag := &models.IdTag{
+		IdTag:       req.IdTag,
+		UserId:      int(req.UserId),
+		ParentIdTag: req.ParentIdTag,
+		Status:      status,
+		UpdatedAt:   toDBTime(time.Now()),
+	}
+	if req.ExpiryDate != "" {
+		t, err := time.Parse(time.RFC3339, req.ExpiryDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiry_date: %w", err)
+		}
+		tag.ExpiryDate = toDBTime(t)
+	}
+	return tag, nil
+}
+
+// newIdTagAccess собирает ограничения idTag из SetIdTag и SetIdTagGroup
+func newIdTagAccess(idTag string, stationIds []int64, locationIds []int64) []*models.IdTagAccess {
+	var access []*models.IdTagAccess
+	for _, stationId := range stationIds {
+		access = append(access, &models.IdTagAccess{IdTag: idTag, StationId: int(stationId)})
+	}
+	for _, locationId := range locationIds {
+		access = append(access, &models.IdTagAccess{IdTag: idTag, LocationId: int(locationId)})
+	}
+	return access
+}
+
+// idTagAccessToProto разделяет ограничения на разрешённые станции и локации
+func idTagAccessToProto(access []*models.IdTagAccess) ([]int64, []int64) {
+	var stationIds, locationIds []int64
+	for _, a := range access {
+		if a.StationId != 0 {
+			stationIds = append(stationIds, int64(a.StationId))
+		}
+		if a.LocationId != 0 {
+			locationIds = append(locationIds, int64(a.LocationId))
+		}
+	}
+	return stationIds, locationIds
+}
+
+func idTagToProto(t *models.IdTag, access []*models.IdTagAccess) *control.IdTag {
+	res := &control.IdTag{
+		IdTag:       t.IdTag,
+		UserId:      int64(t.UserId),
+		ParentIdTag: t.ParentIdTag,
+		Status:      t.Status,
+		ExpiryDate:  t.ExpiryDate,
+		Version:     int64(t.Version),
+		UpdatedAt:   t.UpdatedAt,
+	}
+	res.AllowedStationIds, res.AllowedLocationIds = idTagAccessToProto(access)
+	return res
+}
+
+// newIdTagGroup проверяет параметры SetIdTagGroup и готовит запись группы
+func newIdTagGroup(req *control.SetIdTagGroupRequest) (*models.IdTagGroup, error) {
+	if req.ParentIdTag == "" || len(req.ParentIdTag) > 36 {
+		return nil, fmt.Errorf("parent_id_tag is required and must not be longer than 36 characters")
+	}
+	if req.MaxConcurrentSessions < 0 || req.MonthlyEnergyKwh < 0 {
+		return nil, fmt.Errorf("max_concurrent_sessions and monthly_energy_kwh must not be negative")
+	}
+	return &models.IdTagGroup{
+		ParentIdTag:           req.ParentIdTag,
+		Name:                  req.Name,
+		MaxConcurrentSessions: int(req.MaxConcurrentSessions),
+		MonthlyEnergyKwh:      req.MonthlyEnergyKwh,
+		UpdatedAt:             toDBTime(time.Now()),
+	}, nil
+}
+
+// idTagGroupRestricted сообщает, что группа стала строже: уменьшился лимит или сузились разрешённые станции.
+// previous - группа до изменения, nil - группы не было
+func idTagGroupRestricted(previous, group *models.IdTagGroup, previousAccess, access []*models.IdTagAccess) bool {
+	if previous == nil {
+		previous = &models.IdTagGroup{}
+	}
+	if limitLowered(float64(previous.MaxConcurrentSessions), float64(group.MaxConcurrentSessions)) ||
+		limitLowered(previous.MonthlyEnergyKwh, group.MonthlyEnergyKwh) {
+		return true
+	}
+	if len(access) == 0 {
+		return false
+	}
+	if len(previousAccess) == 0 {
+		return true
+	}
+	allowed := make(map[models.IdTagAccess]bool)
+	for _, a := range access {
+		allowed[models.IdTagAccess{StationId: a.StationId, LocationId: a.LocationId}] = true
+	}
+	for _, a := range previousAccess {
+		if !allowed[models.IdTagAccess{StationId: a.StationId, LocationId: a.LocationId}] {
+			return true
+		}
+	}
+	return false
+}
+
+// limitLowered сравнивает лимиты группы, 0 - без ограничения
+func limitLowered(previous, current float64) bool {
+	return current > 0 && (previous == 0 || current < previous)
+}
+
+func idTagGroupToProto(g *models.IdTagGroup, access []*models.IdTagAccess) *control.IdTagGroup {
+	res := &control.IdTagGroup{
+		ParentIdTag:           g.ParentIdTag,
+		Name:                  g.Name,
+		MaxConcurrentSessions: int64(g.MaxConcurrentSessions),
+		MonthlyEnergyKwh:      g.MonthlyEnergyKwh,
+		UpdatedAt:             g.UpdatedAt,
+	}
+	res.AllowedStationIds, res.AllowedLocationIds = idTagAccessToProto(access)
+	return res
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+)
+
+// fakeLocalList хранит версии списка и карты, которые отдаются в полном и дифференциальном обновлении
+type fakeLocalList struct {
+	fakeIdTags
+	listVersion int
+	synced      int
+	valid       []*models.IdTag
+	changed     []*models.IdTag
+}
+
+func (f *fakeLocalList) GetIdTagListVersion() (int, error) {
+	return f.listVersion, nil
+}
+
+func (f *fakeLocalList) GetLocalListVersion(stationId int) (int, error) {
+	return f.synced, nil
+}
+
+func (f *fakeLocalList) SetLocalListVersion(stationId int, version int, updatedAt string) error {
+	f.synced = version
+	return nil
+}
+
+func (f *fakeLocalList) GetValidIdTags(now string) ([]*models.IdTag, error) {
+	return f.valid, nil
+}
+
+func (f *fakeLocalList) GetIdTagsChangedSince(version int) ([]*models.IdTag, error) {
+	return f.changed, nil
+}
+
+func (f *fakeLocalList) GetIdTagsAccess(idTags []string) ([]*models.IdTagAccess, error) {
+	var access []*models.IdTagAccess
+	for _, idTag := range idTags {
+		access = append(access, f.access[idTag]...)
+	}
+	return access, nil
+}
+
+type fakeConfiguration struct {
+	repository.Configuration
+	keys []*models.ConfigurationKey
+}
+
+func (f fakeConfiguration) GetConfigurationByStationID(stationId int) ([]*models.ConfigurationKey, error) {
+	return f.keys, nil
+}
+
+// sentList - часть локального списка, полученная станцией
+type sentList struct {
+	UpdateType string
+	Tags       []string
+}
+
+// runFakeStation отвечает на GetLocalListVersion версией stationVersion, а на SendLocalList - статусами
+// из statuses по очереди (после их окончания - Accepted). Возвращает полученные станцией части списка
+func runFakeStation(t *testing.T, s *StationService, stationVersion int, statuses []string) *[]sentList {
+	t.Helper()
+	sent := new([]sentList)
+	go func() {
+		for {
+			var msg []byte
+			select {
+			case msg = <-s.outbound:
+			case <-s.done:
+				return
+			}
+			var call []json.RawMessage
+			var id, action string
+			if err := json.Unmarshal(msg, &call); err != nil || len(call) != 4 {
+				t.Errorf("unexpected message %s", msg)
+				return
+			}
+			json.Unmarshal(call[1], &id)
+			json.Unmarshal(call[2], &action)
+			switch action {
+			case "GetLocalListVersion":
+				s.deliverResponse(id, "result", GetLocalListVersionResponse{ListVersion: stationVersion})
+			case "SendLocalList":
+				var req SendLocalListRequest
+				json.Unmarshal(call[3], &req)
+				part := sentList{UpdateType: req.UpdateType, Tags: []string{}}
+				for _, entry := range req.LocalAuthorizationList {
+					part.Tags = append(part.Tags, entry.IdTag)
+				}
+				*sent = append(*sent, part)
+				status := "Accepted"
+				if len(statuses) > 0 {
+					status, statuses = statuses[0], statuses[1:]
+				}
+				s.deliverResponse(id, "result", SendLocalListResponse{Status: status})
+			default:
+				t.Errorf("unexpected action %s", action)
+				return
+			}
+		}
+	}()
+	return sent
+}
+
+func newLocalListStationService(store *fakeLocalList, chunkSize int) *StationService {
+	var keys []*models.ConfigurationKey
+	if chunkSize > 0 {
+		keys = append(keys, &models.ConfigurationKey{Key: "SendLocalListMaxLength", Value: fmt.Sprint(chunkSize)})
+	}
+	return &StationService{
+		Station: &models.Station{Id: testStationId, ChargeBoxId: "CP-TEST"},
+		Repository: &repository.Repository{
+			IdTag:         store,
+			Station:       fakeStations{},
+			Configuration: fakeConfiguration{keys: keys},
+		},
+		respChans: make(map[string]chan []byte),
+		outbound:  make(chan []byte, outboundQueueSize),
+		done:      make(chan struct{}),
+	}
+}
+
+func testTags(idTags ...string) []*models.IdTag {
+	var tags []*models.IdTag
+	for _, idTag := range idTags {
+		tags = append(tags, acceptedTag(idTag, 1, ""))
+	}
+	return tags
+}
+
+func TestSyncLocalList(t *testing.T) {
+	tests := []struct {
+		name           string
+		listVersion    int
+		synced         int
+		stationVersion int
+		full           bool
+		chunkSize      int
+		statuses       []string
+		want           []sentList
+		wantVersion    int
+	}{
+		{
+			name:        "no list",
+			wantVersion: 0,
+		},
+		{
+			name:           "station is up to date",
+			listVersion:    5,
+			synced:         5,
+			stationVersion: 5,
+			wantVersion:    5,
+		},
+		{
+			name:           "changes since the synced version",
+			listVersion:    6,
+			synced:         5,
+			stationVersion: 5,
+			want:           []sentList{{UpdateType: "Differential", Tags: []string{"NEW"}}},
+			wantVersion:    6,
+		},
+		{
+			name:           "empty station list",
+			listVersion:    6,
+			synced:         5,
+			stationVersion: 0,
+			want:           []sentList{{UpdateType: "Full", Tags: []string{"A", "B", "C"}}},
+			wantVersion:    6,
+		},
+		{
+			name:           "station version differs from the synced one",
+			listVersion:    6,
+			synced:         5,
+			stationVersion: 3,
+			want:           []sentList{{UpdateType: "Full", Tags: []string{"A", "B", "C"}}},
+			wantVersion:    6,
+		},
+		{
+			name:           "forced full update",
+			listVersion:    5,
+			synced:         5,
+			stationVersion: 5,
+			full:           true,
+			want:           []sentList{{UpdateType: "Full", Tags: []string{"A", "B", "C"}}},
+			wantVersion:    5,
+		},
+		{
+			name:           "version mismatch falls back to full",
+			listVersion:    6,
+			synced:         5,
+			stationVersion: 5,
+			statuses:       []string{"VersionMismatch"},
+			want: []sentList{
+				{UpdateType: "Differential", Tags: []string{"NEW"}},
+				{UpdateType: "Full", Tags: []string{"A", "B", "C"}},
+			},
+			wantVersion: 6,
+		},
+		{
+			name:           "full list in chunks",
+			listVersion:    6,
+			stationVersion: 0,
+			chunkSize:      2,
+			want: []sentList{
+				{UpdateType: "Full", Tags: []string{"A", "B"}},
+				{UpdateType: "Differential", Tags: []string{"C"}},
+			},
+			wantVersion: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeLocalList{
+				listVersion: tt.listVersion,
+				synced:      tt.synced,
+				valid:       testTags("A", "B", "C"),
+				changed:     testTags("NEW"),
+			}
+			s := newLocalListStationService(store, tt.chunkSize)
+			defer close(s.done)
+			sent := runFakeStation(t, s, tt.stationVersion, tt.statuses)
+
+			code, version, err := s.syncLocalList(tt.full)
+			if code != 0 || err != nil {
+				t.Fatalf("syncLocalList() code = %d, err = %v", code, err)
+			}
+			if version != tt.wantVersion {
+				t.Errorf("syncLocalList() version = %d, want %d", version, tt.wantVersion)
+			}
+			if !reflect.DeepEqual(*sent, tt.want) {
+				t.Errorf("sent %+v, want %+v", *sent, tt.want)
+			}
+			if len(tt.want) > 0 && store.synced != tt.listVersion {
+				t.Errorf("synced version = %d, want %d", store.synced, tt.listVersion)
+			}
+		})
+	}
+}
+
+func TestSyncLocalListRejected(t *testing.T) {
+	store := &fakeLocalList{listVersion: 6, synced: 5, changed: testTags("NEW")}
+	s := newLocalListStationService(store, 0)
+	defer close(s.done)
+	runFakeStation(t, s, 5, []string{"Failed"})
+
+	if code, _, err := s.syncLocalList(false); code == 0 || err == nil {
+		t.Fatalf("syncLocalList() code = %d, err = %v, want an error", code, err)
+	}
+	if store.synced != 5 {
+		t.Errorf("synced version = %d, want it unchanged", store.synced)
+	}
+}
+
+func TestLocalListChunkSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "", want: defaultLocalListChunkSize},
+		{value: "20", want: 20},
+		{value: "0", want: defaultLocalListChunkSize},
+		{value: "many", want: defaultLocalListChunkSize},
+	}
+	for _, tt := range tests {
+		var keys []*models.ConfigurationKey
+		if tt.value != "" {
+			keys = append(keys, &models.ConfigurationKey{Key: "SendLocalListMaxLength", Value: tt.value})
+		}
+		s := &StationService{
+			Station:    &models.Station{Id: testStationId},
+			Repository: &repository.Repository{Configuration: fakeConfiguration{keys: keys}},
+		}
+		if got := s.localListChunkSize(); got != tt.want {
+			t.Errorf("localListChunkSize() with %q = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRestrictedIdTags(t *testing.T) {
+	blockedParent := acceptedTag("BLOCKED-FLEET", 0, "")
+	blockedParent.Status = models.IdTagBlocked
+	store := &fakeLocalList{fakeIdTags: fakeIdTags{
+		tags: map[string]*models.IdTag{
+			"FLEET":         acceptedTag("FLEET", 0, ""),
+			"BLOCKED-FLEET": blockedParent,
+		},
+		access: map[string][]*models.IdTagAccess{
+			"OTHER-ST":  {{IdTag: "OTHER-ST", StationId: 2}},
+			"HERE":      {{IdTag: "HERE", StationId: testStationId}},
+			"FLEET":     {{IdTag: "FLEET", LocationId: testLocationId + 1}},
+			"NOT-SENT":  {{IdTag: "NOT-SENT", StationId: 2}},
+			"LOCATION":  {{IdTag: "LOCATION", LocationId: testLocationId}},
+			"UNLIMITED": nil,
+		},
+	}}
+	s := newLocalListStationService(store, 0)
+	tags := append(testTags("OTHER-ST", "HERE", "LOCATION", "UNLIMITED"),
+		acceptedTag("MEMBER", 1, "FLEET"), acceptedTag("BLOCKED-MEMBER", 1, "BLOCKED-FLEET"))
+
+	restricted, err := s.restrictedIdTags(tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range tags {
+		want := tag.IdTag == "OTHER-ST" || tag.IdTag == "MEMBER" || tag.IdTag == "BLOCKED-MEMBER"
+		if got := localListRestricted(tag, restricted); got != want {
+			t.Errorf("localListRestricted(%s) = %v, want %v", tag.IdTag, got, want)
+		}
+	}
+	if restricted["NOT-SENT"] {
+		t.Errorf("restrictedIdTags() looked at a tag that is not in the list")
+	}
+}
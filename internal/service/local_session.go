@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+// sessionForTransaction возвращает сессию, в которой начинается транзакция idTag на коннекторе.
+// Сессию удалённого запуска заранее создаёт приложение, для карты, приложенной на станции,
+// сессия создаётся здесь. tag - запись idTag из авторизации, пустой для idTag удалённого запуска
+func (s *StationService) sessionForTransaction(connectorId int, idTag string, tag *models.IdTag) (*models.Session, error) {
+	session, err := s.Repository.Session.GetCurrentSessionByIdTag(idTag)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil && session.StationId == s.Station.Id && session.WasStartTransaction == 0 {
+		return session, nil
+	}
+	if tag == nil {
+		return nil, nil
+	}
+	return s.createLocalSession(connectorId, tag)
+}
+
+// createLocalSession создаёт текущую сессию для транзакции, начатой картой tag на станции.
+// Описание коннектора, локации и тариф берутся из последней сессии на коннекторе: их заполняет
+// приложение. Без такой сессии тариф неизвестен, и транзакция не начинается
+func (s *StationService) createLocalSession(connectorId int, tag *models.IdTag) (*models.Session, error) {
+	session, err := s.Repository.Session.GetSessionTemplate(s.Station.Id, connectorId)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, fmt.Errorf("tariff of connector %d is unknown: no sessions", connectorId)
+	}
+	session.UserId = tag.UserId
+	session.IdTag = tag.IdTag
+	session.ParentIdTag = tag.ParentIdTag
+	session.WasStartAccepted = 1
+	session.Begin = toDBTime(time.Now())
+	session.End = session.Begin
+	if session.StationSerial == "" {
+		session.StationSerial = s.Station.ChargeBoxSerial
+	}
+	if err := s.Repository.Session.CreateCurrentSession(session); err != nil {
+		return nil, err
+	}
+	log.Printf("Станция %s: создана сессия %d для idTag %s (пользователь %d) на коннекторе %d, тариф %.2f",
+		s.chargeBoxId(), session.Id, tag.IdTag, tag.UserId, connectorId, session.PricePerKwH)
+	return session, nil
+}
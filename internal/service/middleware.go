@@ -0,0 +1,55 @@
+package service
+
+import (
+	"expvar"
+	"log"
+	"time"
+)
+
+var (
+	// Количество обработанных CALL и CALLERROR по действиям, доступно на /debug/vars
+	actionCalls  = expvar.NewMap("ocpp_action_calls")
+	actionErrors = expvar.NewMap("ocpp_action_errors")
+)
+
+// loggingMiddleware логирует действие, результат и время обработки
+func loggingMiddleware(next ActionHandler) ActionHandler {
+	return func(s *StationService, action string, payload map[string]interface{}) (interface{}, *CallError) {
+		start := time.Now()
+		res, callErr := next(s, action, payload)
+		if callErr != nil {
+			log.Printf("Станция %s: %s завершился ошибкой %s за %v", s.chargeBoxId(), action, callErr.Error(), time.Since(start))
+		} else {
+			log.Printf("Станция %s: %s обработан за %v", s.chargeBoxId(), action, time.Since(start))
+		}
+		return res, callErr
+	}
+}
+
+// metricsMiddleware считает вызовы и ошибки по действиям
+func metricsMiddleware(next ActionHandler) ActionHandler {
+	return func(s *StationService, action string, payload map[string]interface{}) (interface{}, *CallError) {
+		actionCalls.Add(action, 1)
+		res, callErr := next(s, action, payload)
+		if callErr != nil {
+			actionErrors.Add(action+":"+callErr.ErrorCode, 1)
+		}
+		return res, callErr
+	}
+}
+
+// validationMiddleware проверяет запрос и ответ по JSON-схемам OCPP. Ответ формируется после записи в БД,
+// поэтому нарушения в нём только логируются: CALLERROR заставил бы станцию повторить уже обработанный CALL
+func validationMiddleware(next ActionHandler) ActionHandler {
+	return func(s *StationService, action string, payload map[string]interface{}) (interface{}, *CallError) {
+		if callErr := s.validateCall(action, payload); callErr != nil {
+			return nil, callErr
+		}
+		res, callErr := next(s, action, payload)
+		if callErr != nil {
+			return nil, callErr
+		}
+		s.validateCallResult(action, res)
+		return res, nil
+	}
+}
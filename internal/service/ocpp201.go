@@ -0,0 +1,519 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/schema"
+)
+
+// Обработчики OCPP 2.0.1. Данные 2.0.1 переводятся в те же модели, что и 1.6J:
+// EVSE соответствует коннектору с ocpp_id = evseId, состояния коннекторов хранятся в терминах 1.6
+
+// Коды CALLERROR в OCPP 2.0.1 отличаются написанием от 1.6J
+var ocpp201ErrorCodes = map[string]string{
+	callErrorFormationViolation:           "FormatViolation",
+	callErrorOccurenceConstraintViolation: "OccurrenceConstraintViolation",
+}
+
+type IdToken201 struct {
+	IdToken string `json:"idToken"`
+	Type    string `json:"type"`
+}
+
+func (t IdToken201) Validate() error {
+	if err := requireString("idToken.idToken", t.IdToken, 36); err != nil {
+		return err
+	}
+	return checkEnum("idToken.type", t.Type, "Central", "eMAID", "ISO14443", "ISO15693", "KeyCode", "Local",
+		"MacAddress", "NoAuthorization")
+}
+
+type EVSE201 struct {
+	Id          int `json:"id"`
+	ConnectorId int `json:"connectorId,omitempty"`
+}
+
+type IdTokenInfo201 struct {
+	Status              string      `json:"status"`
+	CacheExpiryDateTime string      `json:"cacheExpiryDateTime,omitempty"`
+	GroupIdToken        *IdToken201 `json:"groupIdToken,omitempty"`
+}
+
+type BootNotificationRequest201 struct {
+	ChargingStation struct {
+		SerialNumber    string `json:"serialNumber,omitempty"`
+		Model           string `json:"model"`
+		VendorName      string `json:"vendorName"`
+		FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	} `json:"chargingStation"`
+	Reason string `json:"reason"`
+}
+
+func (r BootNotificationRequest201) Validate() error {
+	if err := requireString("chargingStation.vendorName", r.ChargingStation.VendorName, 50); err != nil {
+		return err
+	}
+	if err := requireString("chargingStation.model", r.ChargingStation.Model, 20); err != nil {
+		return err
+	}
+	return checkEnum("reason", r.Reason, "ApplicationReset", "FirmwareUpdate", "LocalReset", "PowerUp", "RemoteReset",
+		"ScheduledReset", "Triggered", "Unknown", "Watchdog")
+}
+
+type BootNotificationResponse201 struct {
+	CurrentTime string `json:"currentTime"`
+	Interval    int    `json:"interval"`
+	Status      string `json:"status"`
+}
+
+func (s *StationService) handleBootNotification201(req BootNotificationRequest201) (BootNotificationResponse201, *CallError) {
+	log.Printf("BootNotification 2.0.1 от станции: vendor=%s, model=%s, serial=%s, firmware=%s, reason=%s", req.ChargingStation.VendorName, req.ChargingStation.Model, req.ChargingStation.SerialNumber, req.ChargingStation.FirmwareVersion, req.Reason)
+	s.updateStationInfo(req.ChargingStation.VendorName, req.ChargingStation.Model, req.ChargingStation.SerialNumber, req.ChargingStation.FirmwareVersion)
+	s.onBootNotification()
+	return BootNotificationResponse201{
+		CurrentTime: time.Now().UTC().Format(time.RFC3339),
+		Interval:    int(s.getHeartbeatInterval().Seconds()),
+		Status:      "Accepted",
+	}, nil
+}
+
+type StatusNotificationRequest201 struct {
+	Timestamp       string `json:"timestamp"`
+	ConnectorStatus string `json:"connectorStatus"`
+	EvseId          int    `json:"evseId"`
+	ConnectorId     int    `json:"connectorId"`
+}
+
+func (r StatusNotificationRequest201) Validate() error {
+	if err := checkTimestamp("timestamp", r.Timestamp); err != nil {
+		return err
+	}
+	if err := checkMin("evseId", r.EvseId, 0); err != nil {
+		return err
+	}
+	return checkEnum("connectorStatus", r.ConnectorStatus, "Available", "Occupied", "Reserved", "Unavailable", "Faulted")
+}
+
+type StatusNotificationResponse201 struct{}
+
+func (s *StationService) handleStatusNotification201(req StatusNotificationRequest201) (StatusNotificationResponse201, *CallError) {
+	log.Printf("StatusNotification 2.0.1 от станции %d: evseId=%d, connectorId=%d, status=%s", s.Station.Id, req.EvseId, req.ConnectorId, req.ConnectorStatus)
+	state := strings.ToLower(req.ConnectorStatus)
+	if req.ConnectorStatus == "Occupied" {
+		// Точное состояние зарядки придёт в TransactionEvent.chargingState
+		state = "preparing"
+		if connector, err := s.Repository.Connector.Get(s.Station.Id, req.EvseId); err == nil && connector != nil && isActiveConnectorState(connector.State) {
+			state = connector.State
+		}
+	}
+	s.setConnectorState(req.EvseId, state)
+	return StatusNotificationResponse201{}, nil
+}
+
+func isActiveConnectorState(state string) bool {
+	return state == "charging" || state == "suspendedev" || state == "suspendedevse" || state == "finishing"
+}
+
+type HeartbeatRequest201 struct{}
+
+type HeartbeatResponse201 struct {
+	CurrentTime string `json:"currentTime"`
+}
+
+func (s *StationService) handleHeartbeat201(req HeartbeatRequest201) (HeartbeatResponse201, *CallError) {
+	log.Printf("Heartbeat 2.0.1 от станции: id=%d", s.Station.Id)
+	return HeartbeatResponse201{CurrentTime: time.Now().UTC().Format(time.RFC3339)}, nil
+}
+
+type AuthorizeRequest201 struct {
+	IdToken IdToken201 `json:"idToken"`
+}
+
+func (r AuthorizeRequest201) Validate() error {
+	return r.IdToken.Validate()
+}
+
+type AuthorizeResponse201 struct {
+	IdTokenInfo IdTokenInfo201 `json:"idTokenInfo"`
+}
+
+func (s *StationService) handleAuthorize201(req AuthorizeRequest201) (AuthorizeResponse201, *CallError) {
+	log.Printf("Authorize 2.0.1: idToken=%s, type=%s", req.IdToken.IdToken, req.IdToken.Type)
+	return AuthorizeResponse201{IdTokenInfo: *s.authorizeIdTag("Authorize", req.IdToken.IdToken).idTokenInfo201()}, nil
+}
+
+type SampledValue201 struct {
+	Value         float64 `json:"value"`
+	Context       string  `json:"context,omitempty"`
+	Measurand     string  `json:"measurand,omitempty"`
+	Phase         string  `json:"phase,omitempty"`
+	Location      string  `json:"location,omitempty"`
+	UnitOfMeasure *struct {
+		Unit       string `json:"unit,omitempty"`
+		Multiplier int    `json:"multiplier,omitempty"`
+	} `json:"unitOfMeasure,omitempty"`
+}
+
+type MeterValue201 struct {
+	Timestamp    string            `json:"timestamp"`
+	SampledValue []SampledValue201 `json:"sampledValue"`
+}
+
+// toMeterValues16 переводит показания 2.0.1 в формат 1.6J, в котором их обрабатывает applyMeterValues.
+// Множитель применяется здесь, единица передаётся как есть: энергию в кВт·ч переводит applyMeterValues
+func toMeterValues16(meterValues []MeterValue201) []MeterValueStruct {
+	result := make([]MeterValueStruct, 0, len(meterValues))
+	for _, mv := range meterValues {
+		converted := MeterValueStruct{Timestamp: mv.Timestamp}
+		for _, sv := range mv.SampledValue {
+			measurand := sv.Measurand
+			if measurand == "" {
+				// Значение по умолчанию в OCPP 2.0.1
+				measurand = "Energy.Active.Import.Register"
+			}
+			value := sv.Value
+			unit := ""
+			if sv.UnitOfMeasure != nil {
+				unit = sv.UnitOfMeasure.Unit
+				for i := 0; i < sv.UnitOfMeasure.Multiplier; i++ {
+					value *= 10
+				}
+				for i := 0; i > sv.UnitOfMeasure.Multiplier; i-- {
+					value /= 10
+				}
+			}
+			converted.SampledValue = append(converted.SampledValue, SampledValue{
+				Value:     strconv.FormatFloat(value, 'f', -1, 64),
+				Context:   sv.Context,
+				Measurand: measurand,
+				Phase:     sv.Phase,
+				Location:  sv.Location,
+				Unit:      unit,
+			})
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+type TransactionEventRequest201 struct {
+	EventType       string `json:"eventType"`
+	Timestamp       string `json:"timestamp"`
+	TriggerReason   string `json:"triggerReason"`
+	SeqNo           int    `json:"seqNo"`
+	Offline         bool   `json:"offline,omitempty"`
+	ReservationId   int    `json:"reservationId,omitempty"`
+	TransactionInfo struct {
+		TransactionId string `json:"transactionId"`
+		ChargingState string `json:"chargingState,omitempty"`
+		StoppedReason string `json:"stoppedReason,omitempty"`
+		RemoteStartId int    `json:"remoteStartId,omitempty"`
+	} `json:"transactionInfo"`
+	IdToken    *IdToken201     `json:"idToken,omitempty"`
+	Evse       *EVSE201        `json:"evse,omitempty"`
+	MeterValue []MeterValue201 `json:"meterValue,omitempty"`
+}
+
+func (r TransactionEventRequest201) Validate() error {
+	if err := checkEnum("eventType", r.EventType, "Started", "Updated", "Ended"); err != nil {
+		return err
+	}
+	if err := checkTimestamp("timestamp", r.Timestamp); err != nil {
+		return err
+	}
+	if err := requireString("transactionInfo.transactionId", r.TransactionInfo.TransactionId, 36); err != nil {
+		return err
+	}
+	if r.IdToken != nil {
+		return r.IdToken.Validate()
+	}
+	return nil
+}
+
+type TransactionEventResponse201 struct {
+	IdTokenInfo *IdTokenInfo201 `json:"idTokenInfo,omitempty"`
+}
+
+func (s *StationService) handleTransactionEvent201(req TransactionEventRequest201) (TransactionEventResponse201, *CallError) {
+	log.Printf("TransactionEvent: eventType=%s, transactionId=%s, triggerReason=%s, chargingState=%s, seqNo=%d", req.EventType, req.TransactionInfo.TransactionId, req.TriggerReason, req.TransactionInfo.ChargingState, req.SeqNo)
+	res := TransactionEventResponse201{}
+
+	var auth authorization
+	session, err := s.Repository.Session.GetCurrentSessionByTransactionId(s.Station.Id, req.TransactionInfo.TransactionId)
+	if err != nil {
+		log.Printf("Ошибка поиска сессии по transactionId %s: %v", req.TransactionInfo.TransactionId, err)
+		return res, nil
+	}
+	if session == nil {
+		if req.IdToken != nil {
+			if auth = s.authorizeIdTag("TransactionEvent", req.IdToken.IdToken); !auth.accepted() {
+				res.IdTokenInfo = auth.idTokenInfo201()
+				return res, nil
+			}
+		}
+		var reservation *models.Reservation
+		if req.IdToken != nil && req.Evse != nil {
+			var allowed bool
+			reservation, allowed = s.checkReservation(req.Evse.Id, req.ReservationId, req.IdToken.IdToken, auth.Tag)
+			if !allowed {
+				res.IdTokenInfo = &IdTokenInfo201{Status: "Invalid"}
+				return res, nil
+			}
+		}
+		session = s.findSessionForTransaction(req)
+		if session == nil && auth.Tag != nil && req.Evse != nil {
+			// Транзакция начата картой на станции
+			if session, err = s.createLocalSession(req.Evse.Id, auth.Tag); err != nil {
+				log.Printf("Станция %s: не удалось создать сессию для idToken %s: %v", s.chargeBoxId(), req.IdToken.IdToken, err)
+			}
+		}
+		if session == nil {
+			log.Printf("Сессия для транзакции %s не найдена", req.TransactionInfo.TransactionId)
+			if req.IdToken != nil {
+				res.IdTokenInfo = &IdTokenInfo201{Status: "Invalid"}
+			}
+			return res, nil
+		}
+		if reservation != nil {
+			s.useReservation(reservation, session.Id)
+		}
+		session.TransactionId = req.TransactionInfo.TransactionId
+		session.WasStartTransaction = 1
+		session.MeterStart = transactionMeterStart(toMeterValues16(req.MeterValue))
+		beginTime, err := schema.ParseDateTime(req.Timestamp)
+		if err == nil {
+			beginTime = beginTime.UTC().Add(time.Hour * 3)
+			session.Begin = beginTime.Format("2006-01-02 15:04:05")
+		}
+		if err := s.Repository.Session.UpdateCurrentSession(session); err != nil {
+			fmt.Println("UpdateCurrentSession:", err)
+		}
+	}
+	if req.IdToken != nil {
+		if auth.Status == "" {
+			// Сессия уже идёт: токен проверяется заново, а не принимается без проверки
+			auth = s.authorizeTransactionToken(session, req.IdToken.IdToken)
+		}
+		res.IdTokenInfo = auth.idTokenInfo201()
+	}
+
+	if state := chargingStateToConnectorState(req.TransactionInfo.ChargingState); state != "" && req.EventType != "Ended" {
+		s.setConnectorState(session.ConnectorOcppId, state)
+	}
+
+	s.applyMeterValues(session, toMeterValues16(req.MeterValue))
+
+	if req.EventType == "Ended" {
+		s.stopSession(session, req.Timestamp)
+	}
+	return res, nil
+}
+
+// authorizeTransactionToken проверяет idToken, присланный в идущей транзакции: токен, начавший
+// сессию, уже проверен, другой токен проверяется как Authorize - ConcurrentTx к нему не относится
+func (s *StationService) authorizeTransactionToken(session *models.Session, idToken string) authorization {
+	if idToken == session.IdTag {
+		return authorization{Status: models.IdTagAccepted, Reason: fmt.Sprintf("idToken начал сессию %d", session.Id)}
+	}
+	return s.authorizeIdTag("Authorize", idToken)
+}
+
+// findSessionForTransaction связывает новую транзакцию станции с текущей сессией:
+// по remoteStartId из RequestStartTransaction, затем по idToken
+func (s *StationService) findSessionForTransaction(req TransactionEventRequest201) *models.Session {
+	if req.TransactionInfo.RemoteStartId != 0 {
+		session, err := s.Repository.Session.GetCurrentSessionByID(req.TransactionInfo.RemoteStartId)
+		if err == nil && session != nil && session.StationId == s.Station.Id {
+			return session
+		}
+	}
+	if req.IdToken != nil {
+		session, err := s.Repository.Session.GetCurrentSessionByIdTag(req.IdToken.IdToken)
+		if err == nil && session != nil && session.StationId == s.Station.Id {
+			return session
+		}
+	}
+	return nil
+}
+
+// chargingStateToConnectorState переводит ChargingStateEnumType 2.0.1 в состояние коннектора 1.6
+func chargingStateToConnectorState(chargingState string) string {
+	switch chargingState {
+	case "Charging":
+		return "charging"
+	case "SuspendedEV":
+		return "suspendedev"
+	case "SuspendedEVSE":
+		return "suspendedevse"
+	case "EVConnected", "Idle":
+		return "preparing"
+	}
+	return ""
+}
+
+type MeterValuesRequest201 struct {
+	EvseId     int             `json:"evseId"`
+	MeterValue []MeterValue201 `json:"meterValue"`
+}
+
+func (r MeterValuesRequest201) Validate() error {
+	if len(r.MeterValue) == 0 {
+		return newCallError(callErrorOccurenceConstraintViolation, "field meterValue is required",
+			map[string]interface{}{"field": "meterValue"})
+	}
+	for _, mv := range r.MeterValue {
+		if err := checkTimestamp("meterValue.timestamp", mv.Timestamp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type MeterValuesResponse201 struct{}
+
+func (s *StationService) handleMeterValues201(req MeterValuesRequest201) (MeterValuesResponse201, *CallError) {
+	log.Printf("MeterValues 2.0.1: evseId=%d, meterValue=%+v", req.EvseId, req.MeterValue)
+	// Показания вне транзакции идут через MeterValues, в транзакции - через TransactionEvent
+	session, err := s.Repository.Session.GetCurrentSessionByConnector(s.Station.Id, req.EvseId)
+	if err == nil && session != nil && session.WasStartTransaction == 1 && session.WasStopTransaction == 0 {
+		s.applyMeterValues(session, toMeterValues16(req.MeterValue))
+	}
+	return MeterValuesResponse201{}, nil
+}
+
+type NotifyEventRequest201 struct {
+	GeneratedAt string `json:"generatedAt"`
+	SeqNo       int    `json:"seqNo"`
+	Tbc         bool   `json:"tbc,omitempty"`
+	EventData   []struct {
+		EventId               int    `json:"eventId"`
+		Timestamp             string `json:"timestamp"`
+		Trigger               string `json:"trigger"`
+		ActualValue           string `json:"actualValue"`
+		EventNotificationType string `json:"eventNotificationType"`
+		Component             struct {
+			Name string `json:"name"`
+		} `json:"component"`
+		Variable struct {
+			Name string `json:"name"`
+		} `json:"variable"`
+	} `json:"eventData"`
+}
+
+type NotifyEventResponse201 struct{}
+
+func (s *StationService) handleNotifyEvent201(req NotifyEventRequest201) (NotifyEventResponse201, *CallError) {
+	for _, event := range req.EventData {
+		log.Printf("NotifyEvent от станции %s: eventId=%d, %s.%s=%s, trigger=%s, type=%s", s.chargeBoxId(), event.EventId, event.Component.Name, event.Variable.Name, event.ActualValue, event.Trigger, event.EventNotificationType)
+	}
+	return NotifyEventResponse201{}, nil
+}
+
+type RequestStartTransactionRequest201 struct {
+	EvseId        int        `json:"evseId,omitempty"`
+	RemoteStartId int        `json:"remoteStartId"`
+	IdToken       IdToken201 `json:"idToken"`
+}
+
+type RequestStartTransactionResponse201 struct {
+	Status        string `json:"status"`
+	TransactionId string `json:"transactionId,omitempty"`
+}
+
+// requestStartTransaction201 запускает зарядку на станции 2.0.1. remoteStartId - id сессии,
+// по нему TransactionEvent(Started) находит сессию
+func (s *StationService) requestStartTransaction201(session *models.Session) (string, error) {
+	req := RequestStartTransactionRequest201{
+		EvseId:        session.ConnectorOcppId,
+		RemoteStartId: session.Id,
+		IdToken:       IdToken201{IdToken: session.IdTag, Type: "Central"},
+	}
+	res := &RequestStartTransactionResponse201{}
+	if err := s.sendRequest("RequestStartTransaction", req, res); err != nil {
+		return "", err
+	}
+	if res.TransactionId != "" {
+		session.TransactionId = res.TransactionId
+	}
+	return res.Status, nil
+}
+
+type RequestStopTransactionRequest201 struct {
+	TransactionId string `json:"transactionId"`
+}
+
+type RequestStopTransactionResponse201 struct {
+	Status string `json:"status"`
+}
+
+func (s *StationService) requestStopTransaction201(sessionId int) (string, error) {
+	session, err := s.Repository.Session.GetCurrentSessionByID(sessionId)
+	if err != nil || session == nil {
+		return "", fmt.Errorf("session %d not found: %v", sessionId, err)
+	}
+	if session.TransactionId == "" {
+		return "", fmt.Errorf("session %d has no transaction on the station", sessionId)
+	}
+	res := &RequestStopTransactionResponse201{}
+	if err := s.sendRequest("RequestStopTransaction", RequestStopTransactionRequest201{TransactionId: session.TransactionId}, res); err != nil {
+		return "", err
+	}
+	return res.Status, nil
+}
+
+type FirmwareStatusNotificationRequest201 struct {
+	Status    string `json:"status"`
+	RequestId int    `json:"requestId,omitempty"`
+}
+
+type FirmwareStatusNotificationResponse201 struct{}
+
+func (s *StationService) handleFirmwareStatusNotification201(req FirmwareStatusNotificationRequest201) (FirmwareStatusNotificationResponse201, *CallError) {
+	log.Printf("FirmwareStatusNotification 2.0.1: status=%s, requestId=%d", req.Status, req.RequestId)
+	s.trackFirmwareStatus(req.Status)
+	return FirmwareStatusNotificationResponse201{}, nil
+}
+
+type LogStatusNotificationRequest201 struct {
+	Status    string `json:"status"`
+	RequestId int    `json:"requestId,omitempty"`
+}
+
+type LogStatusNotificationResponse201 struct{}
+
+func (s *StationService) handleLogStatusNotification201(req LogStatusNotificationRequest201) (LogStatusNotificationResponse201, *CallError) {
+	log.Printf("LogStatusNotification: status=%s, requestId=%d", req.Status, req.RequestId)
+	s.trackLogStatus201(req.Status, req.RequestId)
+	return LogStatusNotificationResponse201{}, nil
+}
+
+type ReservationStatusUpdateRequest201 struct {
+	ReservationId           int    `json:"reservationId"`
+	ReservationUpdateStatus string `json:"reservationUpdateStatus"`
+}
+
+func (r ReservationStatusUpdateRequest201) Validate() error {
+	return checkEnum("reservationUpdateStatus", r.ReservationUpdateStatus, "Expired", "Removed")
+}
+
+type ReservationStatusUpdateResponse201 struct{}
+
+// handleReservationStatusUpdate201 - станция 2.0.1 сообщает, что сняла резервирование сама
+func (s *StationService) handleReservationStatusUpdate201(req ReservationStatusUpdateRequest201) (ReservationStatusUpdateResponse201, *CallError) {
+	log.Printf("ReservationStatusUpdate: reservationId=%d, status=%s", req.ReservationId, req.ReservationUpdateStatus)
+	reservation, err := s.Repository.Reservation.GetReservation(req.ReservationId)
+	if err != nil || reservation == nil || reservation.StationId != s.Station.Id || reservation.Status != models.ReservationActive {
+		return ReservationStatusUpdateResponse201{}, nil
+	}
+	status := models.ReservationCancelled
+	if req.ReservationUpdateStatus == "Expired" {
+		status = models.ReservationExpired
+	}
+	s.setReservationStatus(reservation, status)
+	return ReservationStatusUpdateResponse201{}, nil
+}
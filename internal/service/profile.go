@@ -0,0 +1,103 @@
+package service
+
+import (
+	"log"
+	"sort"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+)
+
+// Состояния расхождения ключа с профилем конфигурации
+const (
+	driftDifferent      = "different"
+	driftMissing        = "missing"
+	driftReadonly       = "readonly"
+	driftRebootRequired = "reboot_required"
+)
+
+// configurationDrift - ключ, значение которого на станции не совпадает с профилем
+type configurationDrift struct {
+	Key      string
+	Expected string
+	Actual   string
+	State    string
+}
+
+// diffConfiguration сравнивает снимок конфигурации станции с профилем
+func diffConfiguration(profile map[string]string, keys []*models.ConfigurationKey) []configurationDrift {
+	byKey := make(map[string]*models.ConfigurationKey, len(keys))
+	for _, k := range keys {
+		byKey[k.Key] = k
+	}
+	names := make([]string, 0, len(profile))
+	for name := range profile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var drift []configurationDrift
+	for _, name := range names {
+		expected := profile[name]
+		actual, ok := byKey[name]
+		switch {
+		case !ok:
+			drift = append(drift, configurationDrift{Key: name, Expected: expected, State: driftMissing})
+		case actual.Value != expected && actual.Readonly:
+			drift = append(drift, configurationDrift{Key: name, Expected: expected, Actual: actual.Value, State: driftReadonly})
+		case actual.Value != expected:
+			drift = append(drift, configurationDrift{Key: name, Expected: expected, Actual: actual.Value, State: driftDifferent})
+		case actual.RebootRequired:
+			drift = append(drift, configurationDrift{Key: name, Expected: expected, Actual: actual.Value, State: driftRebootRequired})
+		}
+	}
+	return drift
+}
+
+// enforceConfigurationProfile выставляет станции ключи из профиля её производителя и модели.
+// Ключи, которые станция не приняла, остаются в снимке со старым значением и видны как расхождение
+func (s *StationService) enforceConfigurationProfile() {
+	if s.cfg == nil || s.Station == nil {
+		return
+	}
+	profile := s.cfg.ConfigurationProfile(s.Station.ChargeBoxVendor, s.Station.ChargeBoxModel)
+	if len(profile) == 0 {
+		return
+	}
+	if err := s.requireOCPP16("ConfigurationProfile"); err != nil {
+		log.Printf("Станция %s: профиль конфигурации не применён: %v", s.chargeBoxId(), err)
+		return
+	}
+
+	_, keys, _, err := s.sendGetConfiguration(nil)
+	if err != nil {
+		log.Printf("Станция %s: не удалось получить конфигурацию для сверки с профилем: %v", s.chargeBoxId(), err)
+		return
+	}
+	for _, d := range diffConfiguration(profile, keys) {
+		if d.State != driftDifferent {
+			log.Printf("Станция %s: ключ %s расходится с профилем (%s), ожидается %q", s.chargeBoxId(), d.Key, d.State, d.Expected)
+			continue
+		}
+		_, status, err := s.sendChangeConfiguration(d.Key, d.Expected)
+		if err != nil {
+			log.Printf("Станция %s: не удалось выставить %s=%s: %v", s.chargeBoxId(), d.Key, d.Expected, err)
+			continue
+		}
+		log.Printf("Станция %s: ключ %s изменён с %q на %q по профилю, статус %s", s.chargeBoxId(), d.Key, d.Actual, d.Expected, status)
+	}
+}
+
+// configurationDriftToProto переводит расхождения в ответ gRPC
+func configurationDriftToProto(drift []configurationDrift) []*control.ConfigurationDrift {
+	result := make([]*control.ConfigurationDrift, 0, len(drift))
+	for _, d := range drift {
+		result = append(result, &control.ConfigurationDrift{
+			Key:      d.Key,
+			Expected: d.Expected,
+			Actual:   d.Actual,
+			State:    d.State,
+		})
+	}
+	return result
+}
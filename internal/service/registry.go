@@ -0,0 +1,158 @@
+package service
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+)
+
+// ActionHandler обрабатывает payload входящего CALL и возвращает payload для CALLRESULT
+type ActionHandler func(s *StationService, action string, payload map[string]interface{}) (interface{}, *CallError)
+
+// Middleware оборачивает ActionHandler (логирование, проверка схем, метрики и т.п.)
+type Middleware func(next ActionHandler) ActionHandler
+
+// ActionRegistry сопоставляет имя действия OCPP с обработчиком.
+// Обработчики производителя (по ChargeBoxVendor) имеют приоритет над общими
+
+type ActionRegistry struct {
+	mu             sync.RWMutex
+	handlers       map[string]ActionHandler
+	vendorHandlers map[string]map[string]ActionHandler
+	middlewares    []Middleware
+}
+
+func NewActionRegistry() *ActionRegistry {
+	return &ActionRegistry{
+		handlers:       make(map[string]ActionHandler),
+		vendorHandlers: make(map[string]map[string]ActionHandler),
+	}
+}
+
+// Register регистрирует обработчик действия
+func (r *ActionRegistry) Register(action string, handler ActionHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[action] = handler
+}
+
+// RegisterVendor регистрирует обработчик действия для станций конкретного производителя
+func (r *ActionRegistry) RegisterVendor(vendor string, action string, handler ActionHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	vendor = strings.ToLower(vendor)
+	if r.vendorHandlers[vendor] == nil {
+		r.vendorHandlers[vendor] = make(map[string]ActionHandler)
+	}
+	r.vendorHandlers[vendor][action] = handler
+}
+
+// Use добавляет middleware. Первый добавленный выполняется первым
+func (r *ActionRegistry) Use(middleware Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middlewares = append(r.middlewares, middleware)
+}
+
+// Lookup возвращает обработчик действия, обёрнутый во все middleware
+func (r *ActionRegistry) Lookup(vendor string, action string) (ActionHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handler, ok := r.vendorHandlers[strings.ToLower(vendor)][action]
+	if !ok {
+		handler, ok = r.handlers[action]
+	}
+	if !ok {
+		return nil, false
+	}
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i](handler)
+	}
+	return handler, true
+}
+
+// HasHandlers сообщает, зарегистрирован ли хотя бы один обработчик
+func (r *ActionRegistry) HasHandlers() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.handlers) > 0
+}
+
+// Handle превращает типизированный обработчик в ActionHandler:
+// payload разбирается в Req, результат Resp уходит в CALLRESULT
+func Handle[Req any, Resp any](fn func(s *StationService, req Req) (Resp, *CallError)) ActionHandler {
+	return func(s *StationService, action string, payload map[string]interface{}) (interface{}, *CallError) {
+		var req Req
+		if callErr := decodeCallPayload(payload, &req); callErr != nil {
+			return nil, callErr
+		}
+		res, callErr := fn(s, req)
+		if callErr != nil {
+			return nil, callErr
+		}
+		return res, nil
+	}
+}
+
+var (
+	// ocpp16Actions - обработчики входящих сообщений OCPP 1.6J
+	ocpp16Actions = newOCPP16Registry()
+	// ocpp201Actions - обработчики входящих сообщений OCPP 2.0.1
+	ocpp201Actions = newOCPP201Registry()
+)
+
+// registryForVersion возвращает обработчики для согласованной версии OCPP
+func registryForVersion(version string) *ActionRegistry {
+	if version == models.OcppVersion201 {
+		return ocpp201Actions
+	}
+	return ocpp16Actions
+}
+
+// SupportsOcppVersion сообщает, можно ли согласовать подпротокол version. Версия без обработчиков
+// не согласуется, чтобы станция не получала NotImplemented на каждое сообщение
+func SupportsOcppVersion(version string) bool {
+	switch version {
+	case models.OcppVersion16, models.OcppVersion201:
+		return registryForVersion(version).HasHandlers()
+	}
+	return false
+}
+
+func newOCPP16Registry() *ActionRegistry {
+	r := NewActionRegistry()
+	r.Use(loggingMiddleware)
+	r.Use(metricsMiddleware)
+	r.Use(validationMiddleware)
+
+	r.Register("BootNotification", Handle((*StationService).handleBootNotification))
+	r.Register("StatusNotification", Handle((*StationService).handleStatusNotification))
+	r.Register("Heartbeat", Handle((*StationService).handleHeartbeat))
+	r.Register("StartTransaction", Handle((*StationService).handleStartTransaction))
+	r.Register("StopTransaction", Handle((*StationService).handleStopTransaction))
+	r.Register("MeterValues", Handle((*StationService).handleMeterValues))
+	r.Register("Authorize", Handle((*StationService).handleAuthorize))
+	r.Register("DataTransfer", Handle((*StationService).handleDataTransfer))
+	r.Register("DiagnosticsStatusNotification", Handle((*StationService).handleDiagnosticsStatusNotification))
+	r.Register("FirmwareStatusNotification", Handle((*StationService).handleFirmwareStatusNotification))
+	return r
+}
+
+func newOCPP201Registry() *ActionRegistry {
+	r := NewActionRegistry()
+	r.Use(loggingMiddleware)
+	r.Use(metricsMiddleware)
+
+	r.Register("BootNotification", Handle((*StationService).handleBootNotification201))
+	r.Register("StatusNotification", Handle((*StationService).handleStatusNotification201))
+	r.Register("Heartbeat", Handle((*StationService).handleHeartbeat201))
+	r.Register("Authorize", Handle((*StationService).handleAuthorize201))
+	r.Register("TransactionEvent", Handle((*StationService).handleTransactionEvent201))
+	r.Register("MeterValues", Handle((*StationService).handleMeterValues201))
+	r.Register("NotifyEvent", Handle((*StationService).handleNotifyEvent201))
+	r.Register("FirmwareStatusNotification", Handle((*StationService).handleFirmwareStatusNotification201))
+	r.Register("LogStatusNotification", Handle((*StationService).handleLogStatusNotification201))
+	r.Register("ReservationStatusUpdate", Handle((*StationService).handleReservationStatusUpdate201))
+	return r
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/schema"
+)
+
+// rejectTransaction сохраняет отклонённую StartTransaction и возвращает её transactionId.
+// Станция завершает такую транзакцию своим StopTransaction, и его нужно сопоставить.
+// 0 - записать транзакцию не удалось
+func (s *StationService) rejectTransaction(req StartTransactionRequest, status string) int {
+	transaction := &models.RejectedTransaction{
+		StationId:   s.Station.Id,
+		ConnectorId: req.ConnectorId,
+		IdTag:       req.IdTag,
+		Status:      status,
+		MeterStart:  req.MeterStart,
+		CreatedAt:   toDBTime(time.Now()),
+	}
+	if t, err := schema.ParseDateTime(req.Timestamp); err == nil {
+		transaction.StartedAt = toDBTime(t)
+	}
+	if err := s.Repository.RejectedTransaction.CreateRejectedTransaction(transaction); err != nil {
+		log.Printf("Станция %s: ошибка сохранения отклонённой транзакции idTag %s: %v", s.chargeBoxId(), req.IdTag, err)
+		return 0
+	}
+	log.Printf("Станция %s: транзакция %d idTag %s на коннекторе %d отклонена: %s", s.chargeBoxId(), transaction.Id, req.IdTag, req.ConnectorId, status)
+	return transaction.Id
+}
+
+// getRejectedTransaction возвращает отклонённую транзакцию станции, nil - transactionId не из отклонённых
+func (s *StationService) getRejectedTransaction(transactionId int) *models.RejectedTransaction {
+	if transactionId == 0 {
+		return nil
+	}
+	transaction, err := s.Repository.RejectedTransaction.GetRejectedTransaction(transactionId)
+	if err != nil {
+		log.Printf("Ошибка поиска отклонённой транзакции %d: %v", transactionId, err)
+		return nil
+	}
+	if transaction == nil || transaction.StationId != s.Station.Id {
+		return nil
+	}
+	return transaction
+}
+
+// stopRejectedTransaction записывает завершение отклонённой транзакции
+func (s *StationService) stopRejectedTransaction(transaction *models.RejectedTransaction, req StopTransactionRequest) {
+	meterStop := req.MeterStop
+	transaction.MeterStop = &meterStop
+	transaction.StoppedAt = toDBTime(time.Now())
+	if t, err := schema.ParseDateTime(req.Timestamp); err == nil {
+		transaction.StoppedAt = toDBTime(t)
+	}
+	if err := s.Repository.RejectedTransaction.UpdateRejectedTransaction(transaction); err != nil {
+		log.Printf("Станция %s: ошибка обновления отклонённой транзакции %d: %v", s.chargeBoxId(), transaction.Id, err)
+		return
+	}
+	log.Printf("Станция %s: отклонённая транзакция %d завершена, meterStop=%d, reason=%s", s.chargeBoxId(), transaction.Id, req.MeterStop, req.Reason)
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+)
+
+// reservationExpiryInterval - как часто истёкшие резервирования переводятся в expired
+const reservationExpiryInterval = 30 * time.Second
+
+// ReserveNowRequest - reservationId совпадает с id записи в reservations
+type ReserveNowRequest struct {
+	ConnectorId   int    `json:"connectorId"`
+	ExpiryDate    string `json:"expiryDate"`
+	IdTag         string `json:"idTag"`
+	ParentIdTag   string `json:"parentIdTag,omitempty"`
+	ReservationId int    `json:"reservationId"`
+}
+
+type ReserveNowResponse struct {
+	Status string `json:"status"`
+}
+
+// ReserveNowRequest201 - без evseId резервируется любой EVSE станции
+type ReserveNowRequest201 struct {
+	Id             int         `json:"id"`
+	ExpiryDateTime string      `json:"expiryDateTime"`
+	IdToken        IdToken201  `json:"idToken"`
+	EvseId         int         `json:"evseId,omitempty"`
+	GroupIdToken   *IdToken201 `json:"groupIdToken,omitempty"`
+}
+
+type CancelReservationRequest struct {
+	ReservationId int `json:"reservationId"`
+}
+
+type CancelReservationResponse struct {
+	Status string `json:"status"`
+}
+
+// sendReserveNow отправляет станции резервирование, сохранённое в reservation.
+// Если станция его не приняла, резервирование помечается rejected. Если ответа нет,
+// неизвестно, держит ли станция резервирование, поэтому оно отменяется отдельной командой
+func (s *StationService) sendReserveNow(reservation *models.Reservation) (int, error) {
+	expiryDate := ""
+	if t, err := fromDBTime(reservation.ExpiryDate); err == nil {
+		expiryDate = t.UTC().Format(time.RFC3339)
+	}
+
+	var req interface{}
+	if s.ocppVersion == models.OcppVersion201 {
+		req201 := ReserveNowRequest201{
+			Id:             reservation.Id,
+			ExpiryDateTime: expiryDate,
+			IdToken:        IdToken201{IdToken: reservation.IdTag, Type: "Central"},
+			EvseId:         reservation.ConnectorId,
+		}
+		if reservation.ParentIdTag != "" {
+			req201.GroupIdToken = &IdToken201{IdToken: reservation.ParentIdTag, Type: "Central"}
+		}
+		req = req201
+	} else {
+		req = ReserveNowRequest{
+			ConnectorId:   reservation.ConnectorId,
+			ExpiryDate:    expiryDate,
+			IdTag:         reservation.IdTag,
+			ParentIdTag:   reservation.ParentIdTag,
+			ReservationId: reservation.Id,
+		}
+	}
+
+	res := &ReserveNowResponse{}
+	if err := s.sendRequest("ReserveNow", req, res); err != nil {
+		if errors.Is(err, errResponseTimeout) {
+			go s.cancelUnconfirmedReservation(reservation)
+		} else {
+			s.setReservationStatus(reservation, models.ReservationRejected)
+		}
+		return sendErrorCode(err), err
+	}
+	log.Printf("ReserveNow ответ: %+v", res)
+
+	if res.Status == "Accepted" {
+		return 0, nil
+	}
+	s.setReservationStatus(reservation, models.ReservationRejected)
+	switch res.Status {
+	case "Occupied":
+		return int(control.ErrorCode_connectorHasActiveSession), fmt.Errorf("ReserveNow status: %s", res.Status)
+	case "Rejected":
+		// Rejected - станция настроена не принимать резервирования
+		return int(control.ErrorCode_commandNotSupported), fmt.Errorf("ReserveNow status: %s", res.Status)
+	}
+	return int(control.ErrorCode_commandWasNotAccepted), fmt.Errorf("ReserveNow status: %s", res.Status)
+}
+
+// sendCancelReservation отменяет действующее резервирование на станции
+func (s *StationService) sendCancelReservation(reservation *models.Reservation) (int, error) {
+	res := &CancelReservationResponse{}
+	if err := s.sendRequest("CancelReservation", CancelReservationRequest{ReservationId: reservation.Id}, res); err != nil {
+		return sendErrorCode(err), err
+	}
+	log.Printf("CancelReservation ответ: %+v", res)
+	if res.Status != "Accepted" {
+		return int(control.ErrorCode_commandWasNotAccepted), fmt.Errorf("CancelReservation status: %s", res.Status)
+	}
+	s.setReservationStatus(reservation, models.ReservationCancelled)
+	return 0, nil
+}
+
+// cancelUnconfirmedReservation снимает резервирование, на которое станция не ответила.
+// Rejected означает, что станция резервирование не знает; если и на отмену ответа нет,
+// резервирование остаётся активным до истечения
+func (s *StationService) cancelUnconfirmedReservation(reservation *models.Reservation) {
+	res := &CancelReservationResponse{}
+	if err := s.sendRequest("CancelReservation", CancelReservationRequest{ReservationId: reservation.Id}, res); err != nil {
+		log.Printf("Станция %s: не удалось отменить неподтверждённое резервирование %d: %v", s.chargeBoxId(), reservation.Id, err)
+		return
+	}
+	if res.Status == "Accepted" {
+		s.setReservationStatus(reservation, models.ReservationCancelled)
+		return
+	}
+	s.setReservationStatus(reservation, models.ReservationRejected)
+}
+
+// checkReservation проверяет резервирование коннектора перед началом транзакции idTag.
+// Возвращает резервирование, которое использует транзакция, и false, если коннектор
+// зарезервирован за другим idTag. reservationId - резервирование, указанное станцией,
+// tag - авторизованная карта, её parentIdTag сверяется с parentIdTag резервирования
+func (s *StationService) checkReservation(connectorId int, reservationId int, idTag string, tag *models.IdTag) (*models.Reservation, bool) {
+	var reservation *models.Reservation
+	var err error
+	if reservationId != 0 {
+		reservation, err = s.Repository.Reservation.GetReservation(reservationId)
+		if reservation != nil && (reservation.StationId != s.Station.Id || reservation.Status != models.ReservationActive) {
+			reservation = nil
+		}
+	}
+	if reservation == nil && err == nil {
+		reservation, err = s.Repository.Reservation.GetActiveReservationByConnector(s.Station.Id, connectorId)
+	}
+	if reservation == nil && err == nil {
+		// Резервирование всей станции держит один коннектор: оно мешает, только если свободных больше нет
+		reservation, err = s.Repository.Reservation.GetActiveReservationByConnector(s.Station.Id, 0)
+		if reservation != nil && s.hasOtherAvailableConnector(connectorId) {
+			reservation = nil
+		}
+	}
+	if err != nil {
+		// Ошибка базы не должна мешать зарядке
+		log.Printf("Ошибка поиска резервирования коннектора %d: %v", connectorId, err)
+		return nil, true
+	}
+	if reservation == nil {
+		return nil, true
+	}
+	if expiry, err := fromDBTime(reservation.ExpiryDate); err == nil && !expiry.After(time.Now()) {
+		s.setReservationStatus(reservation, models.ReservationExpired)
+		return nil, true
+	}
+	if reservation.IdTag == idTag || (reservation.ParentIdTag != "" && tag != nil && tag.ParentIdTag == reservation.ParentIdTag) {
+		return reservation, true
+	}
+	log.Printf("Станция %s: коннектор %d зарезервирован (%d) за другим idTag, %s отклонён", s.chargeBoxId(), connectorId, reservation.Id, idTag)
+	return nil, false
+}
+
+// hasOtherAvailableConnector сообщает, есть ли на станции свободный коннектор, кроме connectorId
+func (s *StationService) hasOtherAvailableConnector(connectorId int) bool {
+	connectors, err := s.Repository.Connector.GetByStationID(s.Station.Id)
+	if err != nil {
+		log.Printf("Ошибка получения коннекторов станции %d: %v", s.Station.Id, err)
+		return false
+	}
+	for _, connector := range connectors {
+		if connector.Id != 0 && connector.Id != connectorId && connector.State == "available" {
+			return true
+		}
+	}
+	return false
+}
+
+// useReservation отмечает, что по резервированию началась сессия
+func (s *StationService) useReservation(reservation *models.Reservation, sessionId int) {
+	reservation.SessionId = sessionId
+	s.setReservationStatus(reservation, models.ReservationUsed)
+}
+
+func (s *StationService) setReservationStatus(reservation *models.Reservation, status string) {
+	if err := setReservationStatus(s.Repository.Reservation, reservation, status); err != nil {
+		log.Printf("Ошибка обновления резервирования %d: %v", reservation.Id, err)
+		return
+	}
+	log.Printf("Станция %s, резервирование %d: %s", s.chargeBoxId(), reservation.Id, status)
+}
+
+func setReservationStatus(repo repository.Reservation, reservation *models.Reservation, status string) error {
+	reservation.Status = status
+	reservation.UpdatedAt = toDBTime(time.Now())
+	return repo.UpdateReservation(reservation)
+}
+
+// ReservationExpirer переводит в expired резервирования, срок которых истёк. Станция снимает
+// резервирование сама, поэтому команда ей не отправляется
+type ReservationExpirer struct {
+	repo *repository.Repository
+}
+
+func NewReservationExpirer(repo *repository.Repository) *ReservationExpirer {
+	return &ReservationExpirer{repo: repo}
+}
+
+// Run проверяет резервирования до завершения процесса
+func (e *ReservationExpirer) Run() {
+	ticker := time.NewTicker(reservationExpiryInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		reservations, err := e.repo.Reservation.GetExpiredReservations(toDBTime(time.Now()))
+		if err != nil {
+			log.Printf("Ошибка получения истёкших резервирований: %v", err)
+			continue
+		}
+		for _, reservation := range reservations {
+			if err := setReservationStatus(e.repo.Reservation, reservation, models.ReservationExpired); err != nil {
+				log.Printf("Ошибка обновления резервирования %d: %v", reservation.Id, err)
+				continue
+			}
+			log.Printf("Резервирование %d станции %d истекло", reservation.Id, reservation.StationId)
+		}
+	}
+}
+
+// newReservation проверяет параметры ReserveNow и готовит запись резервирования
+func newReservation(req *control.ReserveNowRequest) (*models.Reservation, error) {
+	if req.IdTag == "" || req.ConnectorId < 0 {
+		return nil, fmt.Errorf("id_tag is required and connector_id must not be negative")
+	}
+	expiry, err := time.Parse(time.RFC3339, req.ExpiryDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry_date: %w", err)
+	}
+	if !expiry.After(time.Now()) {
+		return nil, fmt.Errorf("expiry_date %s is in the past", req.ExpiryDate)
+	}
+	now := toDBTime(time.Now())
+	return &models.Reservation{
+		StationId:   int(req.StationId),
+		ConnectorId: int(req.ConnectorId),
+		IdTag:       req.IdTag,
+		ParentIdTag: req.ParentIdTag,
+		ExpiryDate:  toDBTime(expiry),
+		Status:      models.ReservationActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func reservationToProto(r *models.Reservation) *control.Reservation {
+	return &control.Reservation{
+		Id:          int64(r.Id),
+		StationId:   int64(r.StationId),
+		ConnectorId: int64(r.ConnectorId),
+		IdTag:       r.IdTag,
+		ParentIdTag: r.ParentIdTag,
+		ExpiryDate:  r.ExpiryDate,
+		Status:      r.Status,
+		SessionId:   int64(r.SessionId),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
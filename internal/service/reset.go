@@ -0,0 +1,49 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+)
+
+type ResetRequest struct {
+	Type string `json:"type"`
+}
+
+type ResetResponse struct {
+	Status string `json:"status"`
+}
+
+// ResetRequest201 - в OCPP 2.0.1 Soft/Hard заменены на OnIdle/Immediate
+type ResetRequest201 struct {
+	Type string `json:"type"`
+}
+
+// sendReset отправляет станции Reset и возвращает статус из её ответа
+func (s *StationService) sendReset(resetType control.ResetType) (int, string, error) {
+	var req interface{}
+	if s.ocppVersion == models.OcppVersion201 {
+		req = ResetRequest201{Type: "OnIdle"}
+		if resetType == control.ResetType_hard {
+			req = ResetRequest201{Type: "Immediate"}
+		}
+	} else {
+		req = ResetRequest{Type: "Soft"}
+		if resetType == control.ResetType_hard {
+			req = ResetRequest{Type: "Hard"}
+		}
+	}
+
+	res := &ResetResponse{}
+	if err := s.sendRequest("Reset", req, res); err != nil {
+		return sendErrorCode(err), "", err
+	}
+	log.Printf("Reset ответ: %+v", res)
+
+	if res.Status != "Accepted" && res.Status != "Scheduled" {
+		return int(control.ErrorCode_commandWasNotAccepted), res.Status, fmt.Errorf("Reset status: %s", res.Status)
+	}
+	return 0, res.Status, nil
+}
@@ -4,17 +4,21 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/delevopersmoke/ocpp_microservice/internal/config"
 	"github.com/delevopersmoke/ocpp_microservice/internal/models"
 	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
 	"github.com/delevopersmoke/ocpp_microservice/internal/repository"
+	"github.com/delevopersmoke/ocpp_microservice/internal/schema"
 	"github.com/gorilla/websocket"
 )
 
@@ -28,6 +32,34 @@ type StationService struct {
 	mu        sync.Mutex
 	respChans map[string]chan []byte
 	respMu    sync.Mutex
+
+	ocppVersion    string
+	registry       *ActionRegistry
+	validationMode string
+	cfg            *config.Config
+
+	outbound  chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+
+	// heartbeatInterval - интервал Heartbeat в наносекундах, меняется принятым ChangeConfiguration
+	heartbeatInterval atomic.Int64
+	heartbeatChanged  chan struct{}
+	missedHeartbeats  int
+	pingInterval      time.Duration
+	lastMessage       atomic.Int64
+
+	// afterResponse - действия, которые обработчик CALL откладывает до отправки CALLRESULT.
+	// Используется только из горутины чтения
+	afterResponse []func()
+
+	// localListMu не даёт одновременно синхронизировать локальный список станции
+	localListMu sync.Mutex
+
+	// registered - станция на этом соединении прошла BootNotification или уже прислала другой CALL,
+	// то есть принимает команды
+	registered atomic.Bool
 }
 
 // Глобальная map для хранения StationService по stationId
@@ -36,11 +68,18 @@ var (
 	stationServicesMu sync.RWMutex
 )
 
-// AddStationService добавляет сервис в map
+// AddStationService добавляет сервис в map. Если станция уже была подключена,
+// старое соединение закрывается, а его ожидающие запросы завершаются ошибкой
 func AddStationService(stationId int, service *StationService) {
 	stationServicesMu.Lock()
-	defer stationServicesMu.Unlock()
+	old, ok := stationServices[stationId]
 	stationServices[stationId] = service
+	stationServicesMu.Unlock()
+
+	if ok && old != service {
+		log.Printf("Станция %d переподключилась, закрываем старое соединение", stationId)
+		old.Close(websocket.CloseNormalClosure, errConnectionReplaced)
+	}
 }
 
 // GetStationService возвращает сервис по stationId
@@ -51,6 +90,17 @@ func GetStationService(stationId int) (*StationService, bool) {
 	return s, ok
 }
 
+// ConnectedStationServices возвращает сервисы всех подключённых станций
+func ConnectedStationServices() []*StationService {
+	stationServicesMu.RLock()
+	defer stationServicesMu.RUnlock()
+	services := make([]*StationService, 0, len(stationServices))
+	for _, s := range stationServices {
+		services = append(services, s)
+	}
+	return services
+}
+
 // RemoveStationService удаляет сервис по stationId
 func RemoveStationService(stationId int) {
 	stationServicesMu.Lock()
@@ -58,14 +108,47 @@ func RemoveStationService(stationId int) {
 	delete(stationServices, stationId)
 }
 
-func NewStationService(conn *websocket.Conn, repo *repository.Repository, stationId int) *StationService {
+// removeStationServiceIfCurrent удаляет сервис, только если в map лежит именно он,
+// чтобы завершившееся соединение не удалило более новое
+func removeStationServiceIfCurrent(stationId int, service *StationService) bool {
+	stationServicesMu.Lock()
+	defer stationServicesMu.Unlock()
+	if stationServices[stationId] != service {
+		return false
+	}
+	delete(stationServices, stationId)
+	return true
+}
+
+// NewStationService создаёт сервис соединения станции. Если станцию не удалось загрузить,
+// соединение закрывается и возвращается ошибка
+func NewStationService(conn *websocket.Conn, repo *repository.Repository, cfg *config.Config, stationId int) (*StationService, error) {
 	stationService := &StationService{
 		conn:       conn,
 		Repository: repo,
 		respChans:  make(map[string]chan []byte),
+		outbound:   make(chan []byte, outboundQueueSize),
+		done:       make(chan struct{}),
+		cfg:        cfg,
+
+		heartbeatChanged: make(chan struct{}, 1),
+		missedHeartbeats: cfg.MissedHeartbeats(),
+		pingInterval:     cfg.PingInterval(),
 	}
+	stationService.heartbeatInterval.Store(int64(cfg.HeartbeatInterval()))
 	stationService.InitializeStation(stationId)
-	return stationService
+	if stationService.Station == nil {
+		stationService.Close(websocket.CloseInternalServerErr, errStationNotLoaded)
+		return nil, errStationNotLoaded
+	}
+	stationService.validationMode = cfg.ValidationMode(stationService.chargeBoxId())
+	stationService.ocppVersion = conn.Subprotocol()
+	if stationService.ocppVersion == "" {
+		stationService.ocppVersion = models.OcppVersion16
+	}
+	stationService.registry = registryForVersion(stationService.ocppVersion)
+	stationService.Station.OcppVersion = stationService.ocppVersion
+	return stationService, nil
 }
 
 func (s *StationService) InitializeStation(stationId int) {
@@ -78,12 +161,17 @@ func (s *StationService) InitializeStation(stationId int) {
 }
 
 func (s *StationService) HandleStationConnection() {
+	go s.writeLoop()
+	s.startKeepalive()
+	defer s.disconnect()
+
 	for {
 		_, message, err := s.conn.ReadMessage()
 		if err != nil {
 			log.Println("Ошибка чтения сообщения:", err)
 			break
 		}
+		s.touchMessage()
 		log.Printf("Получено сообщение: %s", message)
 
 		var ocppMsg []interface{}
@@ -105,110 +193,107 @@ func (s *StationService) HandleStationConnection() {
 		if msgType == 3 { // Ответ на запрос
 			log.Println("Ответ на запрос")
 			uniqueId, _ := ocppMsg[1].(string)
-			result := ocppMsg[2]
-			s.respMu.Lock()
-			log.Println("Ответ на запрос заблочили")
-			ch, ok := s.respChans[uniqueId]
-			if ok {
-				// Оборачиваем результат в map[string]json.RawMessage для совместимости
-				log.Println("Ответ на запрос отправляем в канал")
-				respMap := map[string]json.RawMessage{"result": {}}
-				if b, err := json.Marshal(result); err == nil {
-					respMap["result"] = b
-				}
-				if b, err := json.Marshal(respMap); err == nil {
-					ch <- b
-				}
-			} else {
-				log.Printf("Нет канала для уникального ID %s", uniqueId)
-			}
-			s.respMu.Unlock()
+			s.deliverResponse(uniqueId, "result", ocppMsg[2])
+			continue
+		}
+
+		if msgType == 4 { // Ошибка в ответ на запрос
+			uniqueId, _ := ocppMsg[1].(string)
+			callErr := parseCallError(ocppMsg)
+			log.Printf("CALLERROR на запрос %s: %s", uniqueId, callErr.Error())
+			s.deliverResponse(uniqueId, "error", callErr)
 			continue
 		}
 
 		if msgType == 2 {
+			uniqueId, _ := ocppMsg[1].(string)
 			if len(ocppMsg) < 4 {
 				log.Println("CALL: недостаточно элементов в сообщении")
+				s.sendCallError(uniqueId, newCallError(callErrorFormationViolation, "CALL must contain 4 elements", nil))
 				continue
 			}
 			msgName, _ := ocppMsg[2].(string)
-			uniqueId, _ := ocppMsg[1].(string)
-			payload, _ := ocppMsg[3].(map[string]interface{})
-
-			if msgName == "StatusNotification" {
-				var req StatusNotificationRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleStatusNotification(uniqueId, req)
-				continue
-			}
-			if msgName == "BootNotification" {
-				var req BootNotificationRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleBootNotification(uniqueId, req)
+			payload, ok := ocppMsg[3].(map[string]interface{})
+			if !ok {
+				s.sendCallError(uniqueId, newCallError(callErrorFormationViolation, "payload must be a JSON object", nil))
 				continue
 			}
-			if msgName == "Heartbeat" {
-				var req HeartbeatRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleHeartbeat(uniqueId, req)
-				continue
-			}
-			if msgName == "StartTransaction" {
-				var req StartTransactionRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleStartTransaction(uniqueId, req)
-				continue
-			}
-			if msgName == "StopTransaction" {
-				var req StopTransactionRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleStopTransaction(uniqueId, req)
-				continue
-			}
-			if msgName == "MeterValues" {
-				var req MeterValuesRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleMeterValues(uniqueId, req)
-				continue
-			}
-			if msgName == "Authorize" {
-				var req AuthorizeRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleAuthorize(uniqueId, req)
+
+			handler, ok := s.registry.Lookup(s.Station.ChargeBoxVendor, msgName)
+			if !ok {
+				log.Printf("Неизвестное действие %s", msgName)
+				s.sendCallError(uniqueId, newCallError(callErrorNotImplemented, fmt.Sprintf("Action %s is not implemented", msgName), nil))
 				continue
 			}
-			if msgName == "DataTransfer" {
-				var req DataTransferRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleDataTransfer(uniqueId, req)
-				continue
+			s.afterResponse = nil
+			if msgName != "BootNotification" && !s.registered.Load() {
+				s.afterResponse = append(s.afterResponse, s.afterReconnect)
 			}
-			if msgName == "DiagnosticsStatusNotification" {
-				var req DiagnosticsStatusNotificationRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleDiagnosticsStatusNotification(uniqueId, req)
+			res, callErr := handler(s, msgName, payload)
+			if callErr != nil {
+				s.sendCallError(uniqueId, callErr)
 				continue
 			}
-			if msgName == "FirmwareStatusNotification" {
-				var req FirmwareStatusNotificationRequest
-				payloadBytes, _ := json.Marshal(payload)
-				_ = json.Unmarshal(payloadBytes, &req)
-				s.handleFirmwareStatusNotification(uniqueId, req)
-				continue
+			s.sendResponse(uniqueId, res)
+			for _, fn := range s.afterResponse {
+				go fn()
 			}
 		}
 	}
 }
 
+// SetOnline отмечает станцию подключённой
+func (s *StationService) SetOnline() {
+	if s.Station == nil {
+		return
+	}
+	s.Station.State = models.StationStateOnline
+	log.Printf("Станция %s подключена по %s", s.chargeBoxId(), s.ocppVersion)
+	if err := s.Repository.Station.SetOnline(s.Station.Id, s.ocppVersion, time.Now().UTC().Add(time.Hour*3)); err != nil {
+		log.Printf("Ошибка обновления состояния станции %d: %v", s.Station.Id, err)
+	}
+}
+
+// disconnect закрывает соединение, удаляет сервис из map и отмечает станцию отключённой
+func (s *StationService) disconnect() {
+	s.Close(websocket.CloseNormalClosure, nil)
+	if s.Station == nil {
+		return
+	}
+	if !removeStationServiceIfCurrent(s.Station.Id, s) {
+		// Станция уже переподключилась, состояние принадлежит новому соединению
+		return
+	}
+	s.Station.State = models.StationStateOffline
+	if err := s.Repository.Station.SetOffline(s.Station.Id, time.Now().UTC().Add(time.Hour*3)); err != nil {
+		log.Printf("Ошибка обновления состояния станции %d: %v", s.Station.Id, err)
+	}
+	log.Printf("Станция %s отключена", s.chargeBoxId())
+}
+
+// deliverResponse передаёт CALLRESULT или CALLERROR в канал ожидающего sendRequest
+func (s *StationService) deliverResponse(uniqueId string, key string, value interface{}) {
+	s.respMu.Lock()
+	defer s.respMu.Unlock()
+	ch, ok := s.respChans[uniqueId]
+	if !ok {
+		log.Printf("Нет канала для уникального ID %s", uniqueId)
+		return
+	}
+	// Оборачиваем результат в map[string]json.RawMessage для совместимости
+	respMap := map[string]json.RawMessage{key: {}}
+	if b, err := json.Marshal(value); err == nil {
+		respMap[key] = b
+	}
+	if b, err := json.Marshal(respMap); err == nil {
+		select {
+		case ch <- b:
+		default:
+			log.Printf("Повторный ответ для уникального ID %s проигнорирован", uniqueId)
+		}
+	}
+}
+
 type StatusNotificationRequest struct {
 	ConnectorId int    `json:"connectorId"`
 	Status      string `json:"status"`
@@ -218,36 +303,37 @@ type StatusNotificationRequest struct {
 type StatusNotificationResponse struct{}
 
 // handleStatusNotification вынесена из handler для переиспользования
-func (s *StationService) handleStatusNotification(uniqueId string, req StatusNotificationRequest) {
-	log.Printf("StatusNotification от станции %s: connectorId=%d, status=%s, errorCode=%s", req.ConnectorId, req.Status, req.ErrorCode)
-	connector, err := s.Repository.Connector.Get(s.Station.Id, req.ConnectorId)
-	if err != nil {
-		log.Printf("Ошибка получения коннектора с ID %d: %v", req.ConnectorId, err)
-	} else {
-		connector.State = strings.ToLower(req.Status)
-		if err := s.Repository.Connector.Update(connector); err != nil {
-			log.Printf("Ошибка обновления статуса коннектора %d: %v", req.ConnectorId, err)
-		} else {
-			log.Printf("Статус коннектора %d обновлен на %s", req.ConnectorId, req.Status)
-			session, err := s.Repository.Session.GetCurrentSessionByConnector(s.Station.Id, req.ConnectorId)
-			if err == nil && session != nil && session.WasStopTransaction == 1 {
-				if connector.State != "charging" && connector.State != "finishing" {
-					log.Printf("Автоматически закрываем сессию %d для коннектора %d, состояние: %s", session.Id, req.ConnectorId, connector.State)
-					err = s.Repository.Session.DeleteCurrentSession(session.Id)
-					err2 := s.Repository.Session.CreateFinishedSession(session)
-					if err != nil || err2 != nil {
-						log.Printf("Ошибка при автозакрытии сессии: %v %v", err, err2)
-					}
-				}
+func (s *StationService) handleStatusNotification(req StatusNotificationRequest) (StatusNotificationResponse, *CallError) {
+	log.Printf("StatusNotification от станции %d: connectorId=%d, status=%s, errorCode=%s", s.Station.Id, req.ConnectorId, req.Status, req.ErrorCode)
+	s.setConnectorState(req.ConnectorId, strings.ToLower(req.Status))
+	return StatusNotificationResponse{}, nil
+}
+
+// setConnectorState сохраняет состояние коннектора и закрывает остановленную сессию,
+// когда кабель освобождён. Состояния хранятся в терминах OCPP 1.6 в нижнем регистре
+func (s *StationService) setConnectorState(connectorId int, state string) {
+	connector, err := s.Repository.Connector.Get(s.Station.Id, connectorId)
+	if err != nil || connector == nil {
+		log.Printf("Ошибка получения коннектора с ID %d: %v", connectorId, err)
+		return
+	}
+	connector.State = state
+	if err := s.Repository.Connector.Update(connector); err != nil {
+		log.Printf("Ошибка обновления статуса коннектора %d: %v", connectorId, err)
+		return
+	}
+	log.Printf("Статус коннектора %d обновлен на %s", connectorId, state)
+	session, err := s.Repository.Session.GetCurrentSessionByConnector(s.Station.Id, connectorId)
+	if err == nil && session != nil && session.WasStopTransaction == 1 {
+		if connector.State != "charging" && connector.State != "finishing" {
+			log.Printf("Автоматически закрываем сессию %d для коннектора %d, состояние: %s", session.Id, connectorId, connector.State)
+			err = s.Repository.Session.DeleteCurrentSession(session.Id)
+			err2 := s.Repository.Session.CreateFinishedSession(session)
+			if err != nil || err2 != nil {
+				log.Printf("Ошибка при автозакрытии сессии: %v %v", err, err2)
 			}
 		}
 	}
-
-	resp := []interface{}{3, uniqueId, StatusNotificationResponse{}}
-	respBytes, _ := json.Marshal(resp)
-	if err := s.conn.WriteMessage(websocket.TextMessage, respBytes); err != nil {
-		log.Println("Ошибка отправки StatusNotification ответа:", err)
-	}
 }
 
 type BootNotificationRequest struct {
@@ -268,24 +354,30 @@ type BootNotificationResponse struct {
 	Status      string `json:"status"`
 }
 
-func (s *StationService) handleBootNotification(uniqueId string, req BootNotificationRequest) {
+func (s *StationService) handleBootNotification(req BootNotificationRequest) (BootNotificationResponse, *CallError) {
 	log.Printf("BootNotification от станции: vendor=%s, model=%s, serial=%s, firmware=%s", req.ChargePointVendor, req.ChargePointModel, req.ChargePointSerialNumber, req.FirmwareVersion)
 
-	s.Station.ChargeBoxVendor = req.ChargePointVendor
-	s.Station.ChargeBoxModel = req.ChargePointModel
-	s.Station.ChargeBoxSerial = req.ChargePointSerialNumber
-	s.Station.ChargeBoxFirmware = req.FirmwareVersion
-	if err := s.Repository.Station.Update(s.Station); err != nil {
-		log.Printf("Ошибка обновления станции в базе данных: %v", err)
-	}
+	s.updateStationInfo(req.ChargePointVendor, req.ChargePointModel, req.ChargePointSerialNumber, req.FirmwareVersion)
+	s.onBootNotification()
 
 	res := BootNotificationResponse{
 		CurrentTime: time.Now().UTC().Format(time.RFC3339),
-		Interval:    60,
+		Interval:    int(s.getHeartbeatInterval().Seconds()),
 		Status:      "Accepted",
 	}
 
-	s.sendResponse(uniqueId, res)
+	return res, nil
+}
+
+// updateStationInfo сохраняет данные станции из BootNotification
+func (s *StationService) updateStationInfo(vendor string, model string, serial string, firmware string) {
+	s.Station.ChargeBoxVendor = vendor
+	s.Station.ChargeBoxModel = model
+	s.Station.ChargeBoxSerial = serial
+	s.Station.ChargeBoxFirmware = firmware
+	if err := s.Repository.Station.Update(s.Station); err != nil {
+		log.Printf("Ошибка обновления станции в базе данных: %v", err)
+	}
 }
 
 type HeartbeatRequest struct{}
@@ -294,12 +386,12 @@ type HeartbeatResponse struct {
 	CurrentTime string `json:"currentTime"`
 }
 
-func (s *StationService) handleHeartbeat(uniqueId string, req HeartbeatRequest) {
+func (s *StationService) handleHeartbeat(req HeartbeatRequest) (HeartbeatResponse, *CallError) {
 	log.Printf("Heartbeat от станции: id=%d", s.Station.Id)
 	res := HeartbeatResponse{
 		CurrentTime: time.Now().UTC().Format(time.RFC3339),
 	}
-	s.sendResponse(uniqueId, res)
+	return res, nil
 }
 
 type StartTransactionRequest struct {
@@ -311,35 +403,49 @@ type StartTransactionRequest struct {
 }
 
 type StartTransactionResponse struct {
-	TransactionId int `json:"transactionId"`
-	IdTagInfo     struct {
-		Status string `json:"status"`
-	} `json:"idTagInfo"`
+	TransactionId int       `json:"transactionId"`
+	IdTagInfo     IdTagInfo `json:"idTagInfo"`
 }
 
-func (s *StationService) handleStartTransaction(uniqueId string, req StartTransactionRequest) {
+func (s *StationService) handleStartTransaction(req StartTransactionRequest) (StartTransactionResponse, *CallError) {
 	log.Printf("StartTransaction: connectorId=%d, idTag=%s, timestamp=%s, meterStart=%d, reservationId=%d", req.ConnectorId, req.IdTag, req.Timestamp, req.MeterStart, req.ReservationId)
 
 	res := StartTransactionResponse{}
 
-	session, err := s.Repository.Session.GetCurrentSessionByIdTag(req.IdTag)
+	auth := s.authorizeIdTag("StartTransaction", req.IdTag)
+	res.IdTagInfo = auth.idTagInfo()
+	if !auth.accepted() {
+		res.TransactionId = s.rejectTransaction(req, res.IdTagInfo.Status)
+		return res, nil
+	}
+	reservation, allowed := s.checkReservation(req.ConnectorId, req.ReservationId, req.IdTag, auth.Tag)
+	if !allowed {
+		res.IdTagInfo.Status = models.IdTagInvalid
+		res.TransactionId = s.rejectTransaction(req, res.IdTagInfo.Status)
+		return res, nil
+	}
+	session, err := s.sessionForTransaction(req.ConnectorId, req.IdTag, auth.Tag)
 	if err != nil || session == nil {
-		res.TransactionId = 0
-		res.IdTagInfo.Status = "Rejected"
-	} else {
-		session.WasStartTransaction = 1
-		res.TransactionId = session.Id
-		res.IdTagInfo.Status = "Accepted"
-		beginTime, err := time.Parse(time.RFC3339, req.Timestamp)
-		if err == nil {
-			beginTime = beginTime.UTC().Add(time.Hour * 3)
-			session.Begin = beginTime.Format("2006-01-02 15:04:05")
-		}
+		log.Printf("Станция %s: нет сессии для транзакции idTag %s на коннекторе %d: %v", s.chargeBoxId(), req.IdTag, req.ConnectorId, err)
+		res.IdTagInfo.Status = models.IdTagInvalid
+		res.TransactionId = s.rejectTransaction(req, res.IdTagInfo.Status)
+		return res, nil
+	}
 
-		_ = s.Repository.UpdateCurrentSession(session)
+	session.WasStartTransaction = 1
+	res.TransactionId = session.Id
+	beginTime, err := schema.ParseDateTime(req.Timestamp)
+	if err == nil {
+		beginTime = beginTime.UTC().Add(time.Hour * 3)
+		session.Begin = beginTime.Format("2006-01-02 15:04:05")
 	}
 
-	s.sendResponse(uniqueId, res)
+	_ = s.Repository.UpdateCurrentSession(session)
+	if reservation != nil {
+		s.useReservation(reservation, session.Id)
+	}
+
+	return res, nil
 }
 
 type StopTransactionRequest struct {
@@ -356,48 +462,55 @@ type StopTransactionResponse struct {
 	} `json:"idTagInfo"`
 }
 
-func (s *StationService) handleStopTransaction(uniqueId string, req StopTransactionRequest) {
+func (s *StationService) handleStopTransaction(req StopTransactionRequest) (StopTransactionResponse, *CallError) {
 	log.Printf("StopTransaction: transactionId=%d, idTag=%s, timestamp=%s, meterStop=%d, reason=%s", req.TransactionId, req.IdTag, req.Timestamp, req.MeterStop, req.Reason)
 	session, err := s.Repository.Session.GetCurrentSessionByID(req.TransactionId)
 
 	res := StopTransactionResponse{}
 	if err != nil || session == nil {
+		if transaction := s.getRejectedTransaction(req.TransactionId); transaction != nil {
+			s.stopRejectedTransaction(transaction, req)
+		}
 		res.IdTagInfo.Status = "Invalid"
 	} else {
-
 		session.ChargedEnergy = float64(req.MeterStop) / 1000
-		connector, _ := s.Repository.Connector.Get(session.StationId, session.ConnectorOcppId)
-		session.WasStopTransaction = 1
-		session.TotalPrice = math.Round(session.ChargedEnergy*session.PricePerKwH*100) / 100
-
-		requestTime, errT1 := time.Parse(time.RFC3339, req.Timestamp)
-		beginTime, errT2 := time.Parse("2006-01-02 15:04:05", session.Begin)
-		if errT1 == nil && errT2 == nil {
-			requestTime = requestTime.UTC().Add(time.Hour * 3)
-			session.TimeLeft = int(requestTime.Sub(beginTime).Seconds())
-			session.End = requestTime.Format("2006-01-02 15:04:05")
-		}
+		s.stopSession(session, req.Timestamp)
+		res.IdTagInfo.Status = "Invalid"
+	}
 
-		if connector.State == "finishing" || connector.State == "charging" {
-			err = s.Repository.Session.UpdateCurrentSession(session)
-			if err != nil {
-				fmt.Println("ERROR UpdateCurrentSession:", err.Error())
-			}
-		} else {
-			err = s.Repository.DeleteCurrentSession(session.Id)
-			if err != nil {
-				fmt.Println("ERROR DeleteCurrentSession:", err.Error())
-			}
-			err = s.Repository.CreateFinishedSession(session)
-			if err != nil {
-				fmt.Println("ERROR CreateFinishedSession:", err.Error())
-			}
-		}
+	return res, nil
+}
 
-		res.IdTagInfo.Status = "Invalid"
+// stopSession фиксирует окончание транзакции. Пока кабель не освобождён, сессия остаётся текущей
+// и закрывается по StatusNotification
+func (s *StationService) stopSession(session *models.Session, timestamp string) {
+	connector, _ := s.Repository.Connector.Get(session.StationId, session.ConnectorOcppId)
+	session.WasStopTransaction = 1
+	session.TotalPrice = math.Round(session.ChargedEnergy*session.PricePerKwH*100) / 100
+
+	requestTime, errT1 := schema.ParseDateTime(timestamp)
+	beginTime, errT2 := time.Parse("2006-01-02 15:04:05", session.Begin)
+	if errT1 == nil && errT2 == nil {
+		requestTime = requestTime.UTC().Add(time.Hour * 3)
+		session.TimeLeft = int(requestTime.Sub(beginTime).Seconds())
+		session.End = requestTime.Format("2006-01-02 15:04:05")
 	}
 
-	s.sendResponse(uniqueId, res)
+	if connector != nil && (connector.State == "finishing" || connector.State == "charging") {
+		err := s.Repository.Session.UpdateCurrentSession(session)
+		if err != nil {
+			fmt.Println("ERROR UpdateCurrentSession:", err.Error())
+		}
+	} else {
+		err := s.Repository.DeleteCurrentSession(session.Id)
+		if err != nil {
+			fmt.Println("ERROR DeleteCurrentSession:", err.Error())
+		}
+		err = s.Repository.CreateFinishedSession(session)
+		if err != nil {
+			fmt.Println("ERROR CreateFinishedSession:", err.Error())
+		}
+	}
 }
 
 type MeterValuesRequest struct {
@@ -423,61 +536,110 @@ type SampledValue struct {
 
 type MeterValuesResponse struct{}
 
-func (s *StationService) handleMeterValues(uniqueId string, req MeterValuesRequest) {
+func (s *StationService) handleMeterValues(req MeterValuesRequest) (MeterValuesResponse, *CallError) {
 	log.Printf("MeterValues: connectorId=%d, transactionId=%d, meterValue=%+v", req.ConnectorId, req.TransactionId, req.MeterValue)
 
 	session, err := s.Repository.Session.GetCurrentSessionByID(req.TransactionId)
-	if err != nil {
+	if err == nil && session != nil {
+		s.applyMeterValues(session, req.MeterValue)
+	} else if s.getRejectedTransaction(req.TransactionId) != nil {
+		// Показания отклонённой транзакции в сессии не учитываются
+		log.Printf("Станция %s: MeterValues по отклонённой транзакции %d пропущены", s.chargeBoxId(), req.TransactionId)
+	}
 
-	} else {
-		for _, mv := range req.MeterValue {
-			for _, sv := range mv.SampledValue {
-				if sv.Measurand == "Voltage" {
-					if v, err := strconv.ParseFloat(sv.Value, 32); err == nil {
-						session.Voltage = v
-					}
-				} else if sv.Measurand == "Current.Import" {
-					if v, err := strconv.ParseFloat(sv.Value, 32); err == nil {
-						session.Current = v
-					}
-				} else if sv.Measurand == "Power.Active.Import" {
-					if v, err := strconv.ParseFloat(sv.Value, 32); err == nil {
-						session.Power = v
-					}
-				} else if sv.Measurand == "Energy.Active.Import.Register" {
-					if v, err := strconv.ParseFloat(sv.Value, 32); err == nil {
-						session.ChargedEnergy = v
+	return MeterValuesResponse{}, nil
+}
+
+// applyMeterValues переносит показания счётчика в текущую сессию
+func (s *StationService) applyMeterValues(session *models.Session, meterValues []MeterValueStruct) {
+	if len(meterValues) == 0 {
+		return
+	}
+	for _, mv := range meterValues {
+		for _, sv := range mv.SampledValue {
+			if sv.Measurand == "Voltage" {
+				if v, err := strconv.ParseFloat(sv.Value, 32); err == nil {
+					session.Voltage = v
+				}
+			} else if sv.Measurand == "Current.Import" {
+				if v, err := strconv.ParseFloat(sv.Value, 32); err == nil {
+					session.Current = v
+				}
+			} else if sv.Measurand == "Power.Active.Import" {
+				if v, err := strconv.ParseFloat(sv.Value, 32); err == nil {
+					session.Power = v
+				}
+			} else if sv.Measurand == "Energy.Active.Import.Register" {
+				if s.ocppVersion == models.OcppVersion201 {
+					if v, err := strconv.ParseFloat(sv.Value, 64); err == nil {
+						setMeterReading(session, energyKWh(v, sv.Unit))
 					}
-				} else if sv.Measurand == "SoC" {
-					session.SOC, _ = strconv.Atoi(sv.Value)
+				} else if v, err := strconv.ParseFloat(sv.Value, 32); err == nil {
+					session.ChargedEnergy = v
 				}
+			} else if sv.Measurand == "SoC" {
+				session.SOC, _ = strconv.Atoi(sv.Value)
 			}
 		}
+	}
 
-		if session.WasFirstMeterValues == 0 {
-			session.SOCBegin = session.SOC
-		}
-		if session.Power > session.MaxPower {
-			session.MaxPower = session.Power
-		}
+	if session.WasFirstMeterValues == 0 {
+		session.SOCBegin = session.SOC
+	}
+	if session.Power > session.MaxPower {
+		session.MaxPower = session.Power
+	}
 
-		requestTime, errT1 := time.Parse(time.RFC3339, req.MeterValue[0].Timestamp)
-		beginTime, errT2 := time.Parse("2006-01-02 15:04:05", session.Begin)
-		if errT1 == nil && errT2 == nil {
-			session.TimeLeft = int(requestTime.Add(time.Hour * 3).Sub(beginTime).Seconds())
-		}
+	requestTime, errT1 := schema.ParseDateTime(meterValues[0].Timestamp)
+	beginTime, errT2 := time.Parse("2006-01-02 15:04:05", session.Begin)
+	if errT1 == nil && errT2 == nil {
+		session.TimeLeft = int(requestTime.Add(time.Hour * 3).Sub(beginTime).Seconds())
+	}
 
-		//session.End = time.Now().UTC().Add(time.Hour * 3).Format(time.RFC3339)
-		session.TotalPrice = math.Round(session.ChargedEnergy*session.PricePerKwH*100) / 100
-		session.WasFirstMeterValues = 1
-		err = s.Repository.Session.UpdateCurrentSession(session)
-		if err != nil {
-			fmt.Println("UpdateCurrentSession:", err)
-		}
+	//session.End = time.Now().UTC().Add(time.Hour * 3).Format(time.RFC3339)
+	session.TotalPrice = math.Round(session.ChargedEnergy*session.PricePerKwH*100) / 100
+	session.WasFirstMeterValues = 1
+	err := s.Repository.Session.UpdateCurrentSession(session)
+	if err != nil {
+		fmt.Println("UpdateCurrentSession:", err)
+	}
+}
+
+// energyKWh переводит показание энергии в кВт·ч. Единица по умолчанию в OCPP - Wh
+func energyKWh(value float64, unit string) float64 {
+	if unit == "kWh" {
+		return value
+	}
+	return value / 1000
+}
+
+// setMeterReading пересчитывает энергию сессии OCPP 2.0.1 по показанию счётчика в кВт·ч от показания
+// в начале транзакции. Без него (транзакции, начатые до обновления) показание сохраняется как есть
+func setMeterReading(session *models.Session, register float64) {
+	if session.MeterStart == nil {
+		session.ChargedEnergy = register
+		return
+	}
+	if energy := register - *session.MeterStart; energy >= 0 {
+		session.ChargedEnergy = energy
 	}
+}
 
-	res := MeterValuesResponse{}
-	s.sendResponse(uniqueId, res)
+// transactionMeterStart возвращает показание счётчика в кВт·ч из события начала транзакции 2.0.1,
+// nil - станция его не прислала
+func transactionMeterStart(meterValues []MeterValueStruct) *float64 {
+	for _, mv := range meterValues {
+		for _, sv := range mv.SampledValue {
+			if sv.Measurand != "Energy.Active.Import.Register" {
+				continue
+			}
+			if v, err := strconv.ParseFloat(sv.Value, 64); err == nil {
+				register := energyKWh(v, sv.Unit)
+				return &register
+			}
+		}
+	}
+	return nil
 }
 
 type AuthorizeRequest struct {
@@ -485,22 +647,12 @@ type AuthorizeRequest struct {
 }
 
 type AuthorizeResponse struct {
-	IdTagInfo struct {
-		Status string `json:"status"`
-	} `json:"idTagInfo"`
+	IdTagInfo IdTagInfo `json:"idTagInfo"`
 }
 
-func (s *StationService) handleAuthorize(uniqueId string, req AuthorizeRequest) {
+func (s *StationService) handleAuthorize(req AuthorizeRequest) (AuthorizeResponse, *CallError) {
 	log.Printf("Authorize: idTag=%s", req.IdTag)
-	resp := []interface{}{3, uniqueId, AuthorizeResponse{
-		IdTagInfo: struct {
-			Status string `json:"status"`
-		}{Status: "Accepted"},
-	}}
-	respBytes, _ := json.Marshal(resp)
-	if err := s.conn.WriteMessage(websocket.TextMessage, respBytes); err != nil {
-		log.Println("Ошибка отправки Authorize ответа:", err)
-	}
+	return AuthorizeResponse{IdTagInfo: s.authorizeIdTag("Authorize", req.IdTag).idTagInfo()}, nil
 }
 
 type DataTransferRequest struct {
@@ -514,16 +666,12 @@ type DataTransferResponse struct {
 	Data   interface{} `json:"data,omitempty"`
 }
 
-func (s *StationService) handleDataTransfer(uniqueId string, req DataTransferRequest) {
+func (s *StationService) handleDataTransfer(req DataTransferRequest) (DataTransferResponse, *CallError) {
 	log.Printf("DataTransfer: vendorId=%s, messageId=%s, data=%+v", req.VendorId, req.MessageId, req.Data)
-	resp := []interface{}{3, uniqueId, DataTransferResponse{
+	return DataTransferResponse{
 		Status: "Accepted",
 		Data:   req.Data, // Можно вернуть те же данные или обработать по логике
-	}}
-	respBytes, _ := json.Marshal(resp)
-	if err := s.conn.WriteMessage(websocket.TextMessage, respBytes); err != nil {
-		log.Println("Ошибка отправки DataTransfer ответа:", err)
-	}
+	}, nil
 }
 
 type DiagnosticsStatusNotificationRequest struct {
@@ -532,13 +680,10 @@ type DiagnosticsStatusNotificationRequest struct {
 
 type DiagnosticsStatusNotificationResponse struct{}
 
-func (s *StationService) handleDiagnosticsStatusNotification(uniqueId string, req DiagnosticsStatusNotificationRequest) {
+func (s *StationService) handleDiagnosticsStatusNotification(req DiagnosticsStatusNotificationRequest) (DiagnosticsStatusNotificationResponse, *CallError) {
 	log.Printf("DiagnosticsStatusNotification: status=%s", req.Status)
-	resp := []interface{}{3, uniqueId, DiagnosticsStatusNotificationResponse{}}
-	respBytes, _ := json.Marshal(resp)
-	if err := s.conn.WriteMessage(websocket.TextMessage, respBytes); err != nil {
-		log.Println("Ошибка отправки DiagnosticsStatusNotification ответа:", err)
-	}
+	s.trackDiagnosticsStatus(req.Status)
+	return DiagnosticsStatusNotificationResponse{}, nil
 }
 
 type FirmwareStatusNotificationRequest struct {
@@ -547,13 +692,10 @@ type FirmwareStatusNotificationRequest struct {
 
 type FirmwareStatusNotificationResponse struct{}
 
-func (s *StationService) handleFirmwareStatusNotification(uniqueId string, req FirmwareStatusNotificationRequest) {
+func (s *StationService) handleFirmwareStatusNotification(req FirmwareStatusNotificationRequest) (FirmwareStatusNotificationResponse, *CallError) {
 	log.Printf("FirmwareStatusNotification: status=%s", req.Status)
-	resp := []interface{}{3, uniqueId, FirmwareStatusNotificationResponse{}}
-	respBytes, _ := json.Marshal(resp)
-	if err := s.conn.WriteMessage(websocket.TextMessage, respBytes); err != nil {
-		log.Println("Ошибка отправки FirmwareStatusNotification ответа:", err)
-	}
+	s.trackFirmwareStatus(req.Status)
+	return FirmwareStatusNotificationResponse{}, nil
 }
 
 type RemoteStartTransactionRequest struct {
@@ -590,9 +732,7 @@ func (s *StationService) sendRequest(command string, req interface{}, respObj in
 		delete(s.respChans, id)
 		s.respMu.Unlock()
 	}()
-	if err := s.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
-		//	o.isConnected = false
-		//	_ = o.connection.Close()
+	if err := s.write(msg); err != nil {
 		return err
 	}
 
@@ -609,17 +749,26 @@ func (s *StationService) sendRequest(command string, req interface{}, respObj in
 			}
 			return nil
 		}
+		if e, ok := result["error"]; ok {
+			callErr := &CallError{}
+			if err := json.Unmarshal(e, callErr); err != nil {
+				return err
+			}
+			return callErr
+		}
 		return fmt.Errorf("no result in response")
+	case <-s.done:
+		return s.closeErr
 	case <-time.After(10 * time.Second):
-		return fmt.Errorf("timeout waiting for response")
+		return errResponseTimeout
 	}
 }
 
-func (s *StationService) sendRemoteStartTransaction(sessionId int) int {
+func (s *StationService) sendRemoteStartTransaction(sessionId int) (int, error) {
 	session, err := s.Repository.Session.GetCurrentSessionByID(sessionId)
 	if err != nil || session == nil {
 		fmt.Println("<UNK> <UNK> <UNK> <UNK>:", err)
-		return -1
+		return -1, fmt.Errorf("session %d not found: %v", sessionId, err)
 	}
 
 	session.Begin = time.Now().UTC().Format(time.RFC3339)
@@ -628,23 +777,27 @@ func (s *StationService) sendRemoteStartTransaction(sessionId int) int {
 	err = s.Repository.Session.UpdateCurrentSession(session)
 	if err != nil {
 		fmt.Println("UpdateCurrentSession:", err)
-		return int(control.ErrorCode_errorDB)
-	}
-
-	req := RemoteStartTransactionRequest{
-		ConnectorId: session.ConnectorOcppId,
-		IdTag:       session.IdTag,
+		return int(control.ErrorCode_errorDB), err
 	}
 
 	res := &RemoteStartTransactionResponse{}
-	err = s.sendRequest("RemoteStartTransaction", req, res)
+	var sendErr error
+	if s.ocppVersion == models.OcppVersion201 {
+		res.Status, sendErr = s.requestStartTransaction201(session)
+	} else {
+		req := RemoteStartTransactionRequest{
+			ConnectorId: session.ConnectorOcppId,
+			IdTag:       session.IdTag,
+		}
+		sendErr = s.sendRequest("RemoteStartTransaction", req, res)
+	}
 
-	if err != nil {
+	if sendErr != nil {
 		session.Begin = time.Now().UTC().Add(time.Hour * 3).Format("2006-01-02 15:04:05")
 		session.End = session.Begin
 		err = s.Repository.Session.DeleteCurrentSession(session.Id)
 		err = s.Repository.Session.CreateFinishedSession(session)
-		return int(control.ErrorCode_sendCommandError)
+		return sendErrorCode(sendErr), sendErr
 	}
 
 	if res.Status != "Accepted" {
@@ -653,7 +806,7 @@ func (s *StationService) sendRemoteStartTransaction(sessionId int) int {
 		session.End = session.Begin
 		err = s.Repository.Session.DeleteCurrentSession(session.Id)
 		err = s.Repository.Session.CreateFinishedSession(session)
-		return int(control.ErrorCode_commandWasNotAccepted)
+		return int(control.ErrorCode_commandWasNotAccepted), fmt.Errorf("RemoteStartTransaction status: %s", res.Status)
 	}
 
 	session.WasStartAccepted = 1
@@ -663,7 +816,7 @@ func (s *StationService) sendRemoteStartTransaction(sessionId int) int {
 	}
 
 	log.Printf("RemoteStartTransaction ответ: %+v", res)
-	return 0
+	return 0, nil
 }
 
 type RemoteStopTransactionRequest struct {
@@ -674,23 +827,54 @@ type RemoteStopTransactionResponse struct {
 	Status string `json:"status"`
 }
 
-func (s *StationService) sendRemoteStopTransaction(transactionId int) int {
-	req := RemoteStopTransactionRequest{
-		TransactionId: transactionId,
-	}
-
+func (s *StationService) sendRemoteStopTransaction(transactionId int) (int, error) {
 	res := &RemoteStopTransactionResponse{}
-	err := s.sendRequest("RemoteStopTransaction", req, res)
+	var err error
+	if s.ocppVersion == models.OcppVersion201 {
+		// В 2.0.1 transactionId выдаёт станция, поэтому сюда приходит id сессии
+		res.Status, err = s.requestStopTransaction201(transactionId)
+	} else {
+		req := RemoteStopTransactionRequest{
+			TransactionId: transactionId,
+		}
+		err = s.sendRequest("RemoteStopTransaction", req, res)
+	}
 	if err != nil {
-		return int(control.ErrorCode_sendCommandError)
+		return sendErrorCode(err), err
 	}
 
 	if res.Status != "Accepted" {
-		return int(control.ErrorCode_commandWasNotAccepted)
+		return int(control.ErrorCode_commandWasNotAccepted), fmt.Errorf("RemoteStopTransaction status: %s", res.Status)
 	}
 
 	log.Printf("RemoteStopTransaction ответ: %+v", res)
-	return 0
+	return 0, nil
+}
+
+// sendErrorCode возвращает код ошибки gRPC для ошибки sendRequest
+func sendErrorCode(err error) int {
+	var callErr *CallError
+	if errors.As(err, &callErr) {
+		return int(control.ErrorCode_commandCallError)
+	}
+	return int(control.ErrorCode_sendCommandError)
+}
+
+// dbTimeLayout - формат времени в базе. Время в базе хранится в UTC+3
+const dbTimeLayout = "2006-01-02 15:04:05"
+
+// toDBTime переводит время в формат базы
+func toDBTime(t time.Time) string {
+	return t.UTC().Add(time.Hour * 3).Format(dbTimeLayout)
+}
+
+// fromDBTime разбирает время из базы
+func fromDBTime(s string) (time.Time, error) {
+	t, err := time.Parse(dbTimeLayout, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(-time.Hour * 3), nil
 }
 
 func generateUniqueId() string {
@@ -716,7 +900,7 @@ func generateIdTag() string {
 func (s *StationService) sendResponse(uniqueId string, payload interface{}) {
 	resp := []interface{}{3, uniqueId, payload}
 	respBytes, _ := json.Marshal(resp)
-	if err := s.conn.WriteMessage(websocket.TextMessage, respBytes); err != nil {
+	if err := s.write(respBytes); err != nil {
 		log.Printf("Ошибка отправки ответа: %v", err)
 	}
 }
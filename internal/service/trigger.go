@@ -0,0 +1,78 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+)
+
+// triggerMessages - имена сообщений OCPP 1.6J для TriggerMessage
+var triggerMessages = map[control.TriggerMessageType]string{
+	control.TriggerMessageType_statusNotification:            "StatusNotification",
+	control.TriggerMessageType_meterValues:                   "MeterValues",
+	control.TriggerMessageType_heartbeat:                     "Heartbeat",
+	control.TriggerMessageType_bootNotification:              "BootNotification",
+	control.TriggerMessageType_diagnosticsStatusNotification: "DiagnosticsStatusNotification",
+	control.TriggerMessageType_firmwareStatusNotification:    "FirmwareStatusNotification",
+}
+
+// triggerMessages201 - в OCPP 2.0.1 статус выгрузки диагностики называется LogStatusNotification
+var triggerMessages201 = map[string]string{
+	"DiagnosticsStatusNotification": "LogStatusNotification",
+}
+
+type TriggerMessageRequest struct {
+	RequestedMessage string `json:"requestedMessage"`
+	ConnectorId      int    `json:"connectorId,omitempty"`
+}
+
+type TriggerMessageResponse struct {
+	Status string `json:"status"`
+}
+
+type TriggerMessageRequest201 struct {
+	RequestedMessage string   `json:"requestedMessage"`
+	Evse             *EVSE201 `json:"evse,omitempty"`
+}
+
+// sendTriggerMessage просит станцию отправить сообщение. connectorId = 0 - без привязки к коннектору
+func (s *StationService) sendTriggerMessage(requestedMessage string, connectorId int) (int, string, error) {
+	var req interface{} = TriggerMessageRequest{RequestedMessage: requestedMessage, ConnectorId: connectorId}
+	if s.ocppVersion == models.OcppVersion201 {
+		req201 := TriggerMessageRequest201{RequestedMessage: requestedMessage}
+		if name, ok := triggerMessages201[requestedMessage]; ok {
+			req201.RequestedMessage = name
+		}
+		if connectorId > 0 {
+			req201.Evse = &EVSE201{Id: connectorId}
+		}
+		req = req201
+	}
+
+	res := &TriggerMessageResponse{}
+	if err := s.sendRequest("TriggerMessage", req, res); err != nil {
+		return sendErrorCode(err), "", err
+	}
+	log.Printf("TriggerMessage %s ответ: %+v", requestedMessage, res)
+
+	switch res.Status {
+	case "Accepted":
+		return 0, res.Status, nil
+	case "NotImplemented":
+		return int(control.ErrorCode_commandNotSupported), res.Status, fmt.Errorf("TriggerMessage status: %s", res.Status)
+	}
+	return int(control.ErrorCode_commandWasNotAccepted), res.Status, fmt.Errorf("TriggerMessage status: %s", res.Status)
+}
+
+// triggerStatusOnConnect запрашивает StatusNotification всех коннекторов после переподключения:
+// станция, переподключившаяся без перезагрузки, сама статусы не присылает
+func (s *StationService) triggerStatusOnConnect() {
+	if s.Station == nil {
+		return
+	}
+	if _, _, err := s.sendTriggerMessage("StatusNotification", 0); err != nil {
+		log.Printf("Станция %s: не удалось запросить StatusNotification после подключения: %v", s.chargeBoxId(), err)
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/models"
+	"github.com/delevopersmoke/ocpp_microservice/internal/proto/control"
+)
+
+type UnlockConnectorRequest struct {
+	ConnectorId int `json:"connectorId"`
+}
+
+type UnlockConnectorResponse struct {
+	Status string `json:"status"`
+}
+
+// UnlockConnectorRequest201 - коннектор адресуется внутри EVSE, у наших станций он один
+type UnlockConnectorRequest201 struct {
+	EvseId      int `json:"evseId"`
+	ConnectorId int `json:"connectorId"`
+}
+
+// activeSession возвращает сессию, в которой на коннекторе идёт транзакция
+func (s *StationService) activeSession(connectorId int) (*models.Session, error) {
+	session, err := s.Repository.Session.GetCurrentSessionByConnector(s.Station.Id, connectorId)
+	if err != nil || session == nil {
+		return nil, err
+	}
+	if session.WasStartTransaction == 1 && session.WasStopTransaction == 0 {
+		return session, nil
+	}
+	return nil, nil
+}
+
+// sendUnlockConnector разблокирует коннектор. Во время транзакции команда отклоняется,
+// если не разрешено сначала остановить транзакцию
+func (s *StationService) sendUnlockConnector(connectorId int, stopTransaction bool) (int, string, error) {
+	session, err := s.activeSession(connectorId)
+	if err != nil {
+		return int(control.ErrorCode_errorDB), "", err
+	}
+	if session != nil {
+		if !stopTransaction {
+			return int(control.ErrorCode_connectorHasActiveSession), "", fmt.Errorf("connector %d has active session %d", connectorId, session.Id)
+		}
+		log.Printf("Станция %s: останавливаем сессию %d перед разблокировкой коннектора %d", s.chargeBoxId(), session.Id, connectorId)
+		if code, err := s.sendRemoteStopTransaction(session.Id); code != 0 {
+			return code, "", fmt.Errorf("failed to stop session %d: %w", session.Id, err)
+		}
+	}
+
+	var req interface{} = UnlockConnectorRequest{ConnectorId: connectorId}
+	if s.ocppVersion == models.OcppVersion201 {
+		req = UnlockConnectorRequest201{EvseId: connectorId, ConnectorId: 1}
+	}
+	res := &UnlockConnectorResponse{}
+	if err := s.sendRequest("UnlockConnector", req, res); err != nil {
+		return sendErrorCode(err), "", err
+	}
+	log.Printf("UnlockConnector ответ: %+v", res)
+
+	switch res.Status {
+	case "Unlocked":
+		return 0, res.Status, nil
+	case "NotSupported":
+		return int(control.ErrorCode_commandNotSupported), res.Status, fmt.Errorf("UnlockConnector status: %s", res.Status)
+	case "OngoingAuthorizedTransaction":
+		return int(control.ErrorCode_connectorHasActiveSession), res.Status, fmt.Errorf("UnlockConnector status: %s", res.Status)
+	}
+	return int(control.ErrorCode_commandWasNotAccepted), res.Status, fmt.Errorf("UnlockConnector status: %s", res.Status)
+}
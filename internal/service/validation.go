@@ -0,0 +1,99 @@
+package service
+
+import (
+	"encoding/json"
+	"expvar"
+	"log"
+
+	"github.com/delevopersmoke/ocpp_microservice/internal/schema"
+)
+
+// Режимы проверки JSON-схем OCPP
+const (
+	// validationStrict - нарушения схемы отклоняются CALLERROR
+	validationStrict = "strict"
+	// validationLenient - типы исправляются по схеме, нарушения только логируются
+	validationLenient = "lenient"
+	// validationOff - проверка отключена
+	validationOff = "off"
+)
+
+var (
+	ocpp16Validator *schema.Validator
+
+	// Счётчики нарушений доступны на /debug/vars в ключах "<chargeBoxId>:<action>:<request|response>"
+	schemaViolations = expvar.NewMap("ocpp_schema_violations")
+)
+
+func init() {
+	v, err := schema.NewOCPP16()
+	if err != nil {
+		log.Fatalf("Ошибка загрузки JSON-схем OCPP 1.6: %v", err)
+	}
+	ocpp16Validator = v
+}
+
+// validateCall проверяет payload входящего CALL по схеме OCPP 1.6J
+func (s *StationService) validateCall(action string, payload map[string]interface{}) *CallError {
+	if s.validationMode == validationOff {
+		return nil
+	}
+	if s.validationMode == validationLenient {
+		if _, fixed := ocpp16Validator.CoerceRequest(action, payload); len(fixed) > 0 {
+			log.Printf("Станция %s: в %s исправлены типы полей %v", s.chargeBoxId(), action, fixed)
+			s.countViolations(action, "request", len(fixed))
+		}
+	}
+	violations := ocpp16Validator.ValidateRequest(action, payload)
+	return s.reportViolations(action, violations)
+}
+
+// validateCallResult проверяет payload исходящего CALLRESULT по схеме OCPP 1.6J и логирует нарушения
+func (s *StationService) validateCallResult(action string, payload interface{}) {
+	if s.validationMode == validationOff {
+		return
+	}
+	// Структуры ответов приводим к тому виду, в котором они уйдут в сокет
+	b, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Станция %s: не удалось проверить ответ %s: %v", s.chargeBoxId(), action, err)
+		return
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		log.Printf("Станция %s: не удалось проверить ответ %s: %v", s.chargeBoxId(), action, err)
+		return
+	}
+	// Некорректный ответ - ошибка центральной системы, а не станции: CALLERROR не отправляем
+	for _, v := range ocpp16Validator.ValidateResponse(action, generic) {
+		log.Printf("Станция %s: нарушение схемы %s (response): %s", s.chargeBoxId(), action, v.Error())
+		s.countViolations(action, "response", 1)
+	}
+}
+
+// reportViolations логирует и считает нарушения запроса; в strict режиме возвращает CALLERROR по первому из них
+func (s *StationService) reportViolations(action string, violations []schema.Violation) *CallError {
+	if len(violations) == 0 {
+		return nil
+	}
+	for _, v := range violations {
+		log.Printf("Станция %s: нарушение схемы %s (request): %s", s.chargeBoxId(), action, v.Error())
+	}
+	s.countViolations(action, "request", len(violations))
+	if s.validationMode != validationStrict {
+		return nil
+	}
+	first := violations[0]
+	return newCallError(first.Code, first.Path+" "+first.Message, map[string]interface{}{"field": first.Path})
+}
+
+func (s *StationService) countViolations(action string, direction string, n int) {
+	schemaViolations.Add(s.chargeBoxId()+":"+action+":"+direction, int64(n))
+}
+
+func (s *StationService) chargeBoxId() string {
+	if s.Station == nil {
+		return ""
+	}
+	return s.Station.ChargeBoxId
+}
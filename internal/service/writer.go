@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// writeWait - максимальное время записи одного сообщения в сокет
+	writeWait = 10 * time.Second
+	// outboundQueueSize - размер очереди исходящих сообщений станции
+	outboundQueueSize = 64
+)
+
+var (
+	errConnectionClosed   = errors.New("station connection closed")
+	errConnectionReplaced = errors.New("station connection replaced by a new connection")
+	errHeartbeatTimeout   = errors.New("station heartbeat timeout")
+	errOutboundQueueFull  = errors.New("station outbound queue is full")
+	errStationNotLoaded   = errors.New("station could not be loaded")
+	errResponseTimeout    = errors.New("timeout waiting for response")
+)
+
+// write ставит сообщение в очередь writer-горутины. gorilla/websocket не допускает
+// конкурентных вызовов WriteMessage, поэтому в сокет пишет только writeLoop
+func (s *StationService) write(msg []byte) error {
+	select {
+	case <-s.done:
+		return s.closeErr
+	default:
+	}
+	select {
+	case s.outbound <- msg:
+		return nil
+	case <-s.done:
+		return s.closeErr
+	default:
+		return errOutboundQueueFull
+	}
+}
+
+// writeLoop - единственная горутина, которая пишет в сокет станции
+func (s *StationService) writeLoop() {
+	ping := time.NewTicker(s.pingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ping.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				log.Printf("Станция %s: ошибка отправки ping: %v", s.chargeBoxId(), err)
+			}
+		case msg := <-s.outbound:
+			if err := s.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				log.Printf("Станция %s: ошибка установки дедлайна записи: %v", s.chargeBoxId(), err)
+			}
+			if err := s.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				log.Printf("Станция %s: ошибка записи в сокет: %v", s.chargeBoxId(), err)
+				s.Close(websocket.CloseInternalServerErr, fmt.Errorf("write failed: %w", err))
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Close закрывает соединение со станцией: отправляет close-фрейм, останавливает writer
+// и прерывает ожидающие ответа запросы с ошибкой reason. Повторные вызовы ничего не делают
+func (s *StationService) Close(code int, reason error) {
+	s.closeOnce.Do(func() {
+		if reason == nil {
+			reason = errConnectionClosed
+		}
+		s.closeErr = reason
+		close(s.done)
+		// WriteControl можно вызывать параллельно с WriteMessage
+		_ = s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason.Error()), time.Now().Add(writeWait))
+		if err := s.conn.Close(); err != nil {
+			log.Printf("Станция %s: ошибка закрытия сокета: %v", s.chargeBoxId(), err)
+		}
+	})
+}
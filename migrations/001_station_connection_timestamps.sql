@@ -0,0 +1,5 @@
+ALTER TABLE stations
+    ADD COLUMN connected_at DATETIME NULL,
+    ADD COLUMN disconnected_at DATETIME NULL,
+    -- Станция должна очистить кэш авторизации при следующем подключении
+    ADD COLUMN cache_clear_pending TINYINT(1) NOT NULL DEFAULT 0;
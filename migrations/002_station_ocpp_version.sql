@@ -0,0 +1,2 @@
+ALTER TABLE stations
+    ADD COLUMN ocpp_version VARCHAR(16) NOT NULL DEFAULT 'ocpp1.6';
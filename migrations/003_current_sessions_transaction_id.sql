@@ -0,0 +1,5 @@
+ALTER TABLE current_sessions
+    ADD COLUMN transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    -- Показание счётчика в начале транзакции OCPP 2.0.1, кВт·ч. NULL - показание неизвестно
+    ADD COLUMN meter_start DOUBLE NULL,
+    ADD INDEX idx_current_sessions_transaction (station_id, transaction_id);
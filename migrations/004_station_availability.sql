@@ -0,0 +1,7 @@
+CREATE TABLE station_availability (
+    station_id   INT         NOT NULL,
+    connector_id INT         NOT NULL,
+    availability VARCHAR(16) NOT NULL,
+    updated_at   DATETIME    NOT NULL,
+    PRIMARY KEY (station_id, connector_id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE station_configuration (
+    station_id      INT          NOT NULL,
+    config_key      VARCHAR(50)  NOT NULL,
+    value           VARCHAR(500) NOT NULL DEFAULT '',
+    readonly        TINYINT(1)   NOT NULL DEFAULT 0,
+    reboot_required TINYINT(1)   NOT NULL DEFAULT 0,
+    updated_at      DATETIME     NOT NULL,
+    PRIMARY KEY (station_id, config_key)
+);
@@ -0,0 +1,26 @@
+CREATE TABLE firmware_campaigns (
+    id                INT AUTO_INCREMENT PRIMARY KEY,
+    firmware_url      VARCHAR(512) NOT NULL,
+    target_version    VARCHAR(50)  NOT NULL,
+    retrieve_date     DATETIME     NULL,
+    wave_size         INT          NOT NULL,
+    current_wave      INT          NOT NULL DEFAULT 0,
+    failure_threshold INT          NOT NULL DEFAULT 0,
+    -- Число ошибок на момент последнего возобновления: порог применяется только к новым
+    resumed_failures  INT          NOT NULL DEFAULT 0,
+    station_timeout   INT          NOT NULL,
+    status            VARCHAR(16)  NOT NULL,
+    created_at        DATETIME     NOT NULL,
+    updated_at        DATETIME     NOT NULL
+);
+
+CREATE TABLE firmware_campaign_stations (
+    campaign_id INT          NOT NULL,
+    station_id  INT          NOT NULL,
+    wave        INT          NOT NULL,
+    status      VARCHAR(32)  NOT NULL,
+    error       VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at  DATETIME     NOT NULL,
+    PRIMARY KEY (campaign_id, station_id),
+    KEY idx_firmware_campaign_stations_station (station_id)
+);
@@ -0,0 +1,25 @@
+CREATE TABLE diagnostics_requests (
+    id             INT AUTO_INCREMENT PRIMARY KEY,
+    station_id     INT          NOT NULL,
+    location       VARCHAR(512) NOT NULL,
+    start_time     DATETIME     NULL,
+    stop_time      DATETIME     NULL,
+    retries        INT          NOT NULL DEFAULT 0,
+    retry_interval INT          NOT NULL DEFAULT 0,
+    -- requested, no_file, failed, uploading, uploaded, upload_failed
+    status         VARCHAR(32)  NOT NULL,
+    file_name      VARCHAR(255) NOT NULL DEFAULT '',
+    created_at     DATETIME     NOT NULL,
+    updated_at     DATETIME     NOT NULL,
+    KEY idx_diagnostics_requests_station (station_id)
+);
+
+CREATE TABLE diagnostics_files (
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    station_id INT          NOT NULL,
+    request_id INT          NOT NULL DEFAULT 0,
+    name       VARCHAR(255) NOT NULL,
+    size       BIGINT       NOT NULL,
+    created_at DATETIME     NOT NULL,
+    KEY idx_diagnostics_files_station (station_id)
+);
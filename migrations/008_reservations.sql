@@ -0,0 +1,14 @@
+CREATE TABLE reservations (
+    id            INT AUTO_INCREMENT PRIMARY KEY,
+    station_id    INT         NOT NULL,
+    connector_id  INT         NOT NULL,
+    id_tag        VARCHAR(36) NOT NULL,
+    parent_id_tag VARCHAR(36) NOT NULL DEFAULT '',
+    expiry_date   DATETIME    NOT NULL,
+    status        VARCHAR(16) NOT NULL,
+    session_id    INT         NOT NULL DEFAULT 0,
+    created_at    DATETIME    NOT NULL,
+    updated_at    DATETIME    NOT NULL,
+    KEY idx_reservations_station (station_id, connector_id),
+    KEY idx_reservations_status_expiry (status, expiry_date)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE id_tags (
+    id_tag        VARCHAR(36) PRIMARY KEY,
+    user_id       INT         NOT NULL DEFAULT 0,
+    parent_id_tag VARCHAR(36) NOT NULL DEFAULT '',
+    status        VARCHAR(16) NOT NULL,
+    expiry_date   DATETIME    NULL,
+    version       INT         NOT NULL,
+    updated_at    DATETIME    NOT NULL,
+    KEY idx_id_tags_version (version)
+);
+
+CREATE TABLE station_local_list (
+    station_id INT      PRIMARY KEY,
+    version    INT      NOT NULL,
+    updated_at DATETIME NOT NULL
+);
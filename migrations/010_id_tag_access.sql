@@ -0,0 +1,22 @@
+-- Ограничения idTag по станциям и локациям. Нет строк - idTag действует везде
+CREATE TABLE id_tag_access (
+    id_tag      VARCHAR(36) NOT NULL,
+    station_id  INT         NOT NULL DEFAULT 0,
+    location_id INT         NOT NULL DEFAULT 0,
+    KEY idx_id_tag_access_id_tag (id_tag)
+);
+
+-- Транзакции, отклонённые в StartTransaction. id выдаётся станции как transactionId, чтобы
+-- сопоставить её StopTransaction и MeterValues, поэтому счётчик начинается далеко за id сессий
+CREATE TABLE rejected_transactions (
+    id           INT AUTO_INCREMENT PRIMARY KEY,
+    station_id   INT         NOT NULL,
+    connector_id INT         NOT NULL,
+    id_tag       VARCHAR(36) NOT NULL,
+    status       VARCHAR(32) NOT NULL,
+    meter_start  INT         NOT NULL,
+    meter_stop   INT         NULL,
+    started_at   DATETIME    NULL,
+    stopped_at   DATETIME    NULL,
+    created_at   DATETIME    NOT NULL
+) AUTO_INCREMENT = 1000000000;
@@ -0,0 +1,17 @@
+-- Группы карт с общим parentIdTag (например, карты одной компании). 0 - без ограничения.
+-- Станции и локации группы хранятся в id_tag_access для parent_id_tag
+CREATE TABLE id_tag_groups (
+    parent_id_tag           VARCHAR(36)  NOT NULL PRIMARY KEY,
+    name                    VARCHAR(255) NOT NULL DEFAULT '',
+    max_concurrent_sessions INT          NOT NULL DEFAULT 0,
+    monthly_energy_kwh      DOUBLE       NOT NULL DEFAULT 0,
+    updated_at              DATETIME     NOT NULL
+);
+
+ALTER TABLE current_sessions
+    ADD COLUMN parent_id_tag VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_current_sessions_parent_id_tag (parent_id_tag);
+
+ALTER TABLE finished_sessions
+    ADD COLUMN parent_id_tag VARCHAR(36) NOT NULL DEFAULT '',
+    ADD INDEX idx_finished_sessions_parent_id_tag (parent_id_tag, begin);